@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Command describes a command understood by a TaskListReaderWriter.
+// The same definition is used to dispatch the command, to validate its
+// arguments and to generate the help and usage messages.
+type Command struct {
+	// Name is the first word of the command line.
+	Name string
+	// Aliases are other words accepted in place of Name.
+	Aliases []string
+	// Usages lists the accepted forms of the command, as shown by help.
+	Usages []string
+	// MinArgs and MaxArgs bound the number of arguments following the name.
+	// A negative MaxArgs accepts any number of arguments.
+	MinArgs int
+	MaxArgs int
+	// Mutating marks the commands modifying the TaskList, which is saved after them.
+	Mutating bool
+	// Run executes the command with the arguments following its name, writing
+	// its output to w. Returning an error matching ErrUsage reports the usages,
+	// and returning &UsageError{Err: err} reports them after err.
+	Run func(w io.Writer, args []string) error
+}
+
+func (c *Command) usageError() *UsageError {
+	return &UsageError{
+		Command: c.Name,
+		Usages:  c.Usages,
+	}
+}
+
+// execute validates the number of arguments and runs the command.
+func (c *Command) execute(w io.Writer, args []string) error {
+	if len(args) < c.MinArgs || (c.MaxArgs >= 0 && len(args) > c.MaxArgs) {
+		return c.usageError()
+	}
+
+	err := c.Run(w, args)
+	var usageErr *UsageError
+	switch {
+	case errors.Is(err, ErrUsage):
+		return c.usageError()
+	case errors.As(err, &usageErr) && usageErr.Command == "":
+		usageErr.Command, usageErr.Usages = c.Name, c.Usages
+	}
+	return err
+}
+
+// withUsage reports err along with the usages of the command, which tell how
+// to get past it.
+func withUsage(err error) error {
+	return &UsageError{Err: err}
+}
+
+// Register adds a command. Its name and aliases must not be used by another command.
+func (l *TaskListReaderWriter) Register(command Command) error {
+	if command.Name == "" || command.Run == nil {
+		return fmt.Errorf("command needs a name and a handler")
+	}
+
+	words := append([]string{command.Name}, command.Aliases...)
+	for _, word := range words {
+		if word == quit {
+			return fmt.Errorf("command %q is reserved", word)
+		}
+		if _, ok := l.commandsByName[word]; ok {
+			return fmt.Errorf("command %q is already registered", word)
+		}
+	}
+
+	c := &command
+	l.commands = append(l.commands, c)
+	for _, word := range words {
+		l.commandsByName[word] = c
+	}
+	return nil
+}
+
+// helpMessage lists the usages of every registered command.
+func (l *TaskListReaderWriter) helpMessage() string {
+	var b strings.Builder
+	b.WriteString("Commands:\n")
+	for _, command := range l.commands {
+		for _, usage := range command.Usages {
+			b.WriteString(usage)
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString(quit)
+
+	return b.String()
+}
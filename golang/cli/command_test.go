@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+func newTestTaskListReaderWriter(input string) (*TaskListReaderWriter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	idGenerator := func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}
+
+	return NewTaskListReaderWriter(strings.NewReader(input), out, idGenerator), out
+}
+
+func runToEnd(l *TaskListReaderWriter) error {
+	errorsChan := make(chan error, 1)
+	shutdownChan := make(chan bool, 1)
+	l.Run(errorsChan, shutdownChan)
+
+	select {
+	case err := <-errorsChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+func TestTaskListReaderWriter_Register(t *testing.T) {
+	l, out := newTestTaskListReaderWriter("count\nhow-many\ncount secrets\ncount a b\n")
+	err := l.Register(Command{
+		Name:    "count",
+		Aliases: []string{"how-many"},
+		Usages:  []string{"count [<project name>]"},
+		MaxArgs: 1,
+		Run: func(w io.Writer, args []string) error {
+			if len(args) == 1 && args[0] != "all" {
+				return ErrUsage
+			}
+			fmt.Fprintln(w, len(l.TaskList().GetProjectsWithTasks()))
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "> 0\n> 0\n> could not execute count.\nUsage: count [<project name>]\n> could not execute count.\nUsage: count [<project name>]\n> "
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestTaskListReaderWriter_RegisterRefusesUsedNames(t *testing.T) {
+	l, _ := newTestTaskListReaderWriter("")
+	run := func(io.Writer, []string) error { return nil }
+
+	tests := []Command{
+		{Name: "show", Run: run},
+		{Name: "list", Aliases: []string{"check"}, Run: run},
+		{Name: "quit", Run: run},
+		{Name: "", Run: run},
+		{Name: "nothing"},
+	}
+	for _, command := range tests {
+		if err := l.Register(command); err == nil {
+			t.Errorf("expected an error when registering %+v", command)
+		}
+	}
+}
+
+func TestTaskListReaderWriter_helpListsRegisteredCommands(t *testing.T) {
+	l, out := newTestTaskListReaderWriter("help\n")
+	l.Register(Command{
+		Name:   "count",
+		Usages: []string{"count"},
+		Run:    func(io.Writer, []string) error { return nil },
+	})
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `> Commands:
+show [<query>]
+view by project [<query>]
+view by deadline [<query>]
+view by date [<query>]
+view by priority [<query>]
+view by tag [<query>]
+add project <project name>
+add task <project name> <task description>
+add task(<task ID>) <project name> <task description>
+add task <project name> <task description> !high|!medium|!low #<tag>
+add subtask <parent task ID> <task description>
+check <task ID> [--force]
+uncheck <task ID>
+deadline <task ID> <date>[T<HH:MM> [<time zone>]]
+deadline <task ID> none
+repeat <task ID> every monday|every 2 weeks|monthly on 1
+repeat <task ID> none
+priority <task ID> high|medium|low|none
+tag <task ID> +<tag> [+<tag>...]
+untag <task ID> +<tag> [+<tag>...]
+depends <task ID> on <task ID>
+edit <task ID> <task description>
+move <task ID> <project name>
+rename project <project name> <new project name>
+delete <task ID> [--force]
+delete project <project name> [--force]
+undo
+redo
+today [--all] [<query>]
+overdue [--all] [<query>]
+upcoming [<number of days> days] [--all] [<query>]
+blocked [<query>]
+next [<query>]
+search [--regex|--fuzzy] <term> [<term>...]
+help
+count
+quit
+> `
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+// fixedClock is a Clock always telling the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestTaskListReaderWriter_todayFollowsTheClock(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 23, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	input := "add project secrets\nadd task secrets Eat more donuts.\nadd task secrets Destroy all humans.\ndeadline 1 today\ndeadline 2 tomorrow\ntoday\n"
+	out := &bytes.Buffer{}
+	l := NewTaskListReaderWriter(strings.NewReader(input), out, func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}, tasklist.WithClock(fixedClock(now)))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "> > > > > > secrets\n    [ ] 1: (2024-05-01) Eat more donuts.\n\n> "
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestTaskListReaderWriter_dueViews(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	input := strings.Join([]string{
+		"add project secrets",
+		"add task secrets Eat more donuts.",
+		"add task secrets Destroy all humans.",
+		"add project training",
+		"add task training SOLID",
+		"add task training Outside-In TDD",
+		"add project empty",
+		"deadline 1 2024-04-30",
+		"deadline 2 today",
+		"deadline 3 +3d",
+		"deadline 4 +10d",
+		"check 1",
+		"overdue",
+		"overdue --all",
+		"upcoming",
+		"upcoming 10 days",
+		"upcoming 0 days",
+		"",
+	}, "\n")
+	out := &bytes.Buffer{}
+	l := NewTaskListReaderWriter(strings.NewReader(input), out, func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}, tasklist.WithClock(fixedClock(now)))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Repeat("> ", 13) +
+		"> secrets\n    [X] 1: (2024-04-30) Eat more donuts.\n\n" +
+		"> training\n    [ ] 3: (2024-05-04) SOLID\n\n" +
+		"> training\n    [ ] 3: (2024-05-04) SOLID\n    [ ] 4: (2024-05-11) Outside-In TDD\n\n" +
+		"> could not execute upcoming.\nUsage: upcoming [<number of days> days] [--all] [<query>]\n> "
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestTaskListReaderWriter_deadlinesInTheConfiguredTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// It is already May 2 in Tokyo.
+	now := time.Date(2024, time.May, 1, 20, 0, 0, 0, time.UTC)
+	input := "add project secrets\nadd task secrets Eat more donuts.\nadd task secrets Destroy all humans.\ndeadline 1 2024-05-01T18:00 Europe/Madrid\ndeadline 2 today 9:30\ntoday\n"
+	out := &bytes.Buffer{}
+	l := NewTaskListReaderWriter(strings.NewReader(input), out, func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}, tasklist.WithClock(fixedClock(now)), tasklist.WithLocation(tokyo))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "> > > > > > secrets\n    [ ] 1: (2024-05-02 01:00) Eat more donuts.\n    [ ] 2: (2024-05-02 09:30) Destroy all humans.\n\n> "
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+// Errors reported for invalid command lines. They can be matched with errors.Is.
+const (
+	ErrInvalidCommandLine = tasklist.Error("invalid command line")
+	ErrUnknownCommand     = tasklist.Error("unknown command")
+	// ErrUsage is returned by a Command handler when its arguments are not valid.
+	ErrUsage = tasklist.Error("invalid usage")
+)
+
+// UsageError reports a command called with missing or invalid arguments.
+// Run writes it along with the command usage and keeps reading commands.
+type UsageError struct {
+	Command string
+	Usages  []string
+	// Err is the error that the usages tell how to get past, as with --force,
+	// reported in place of the invalid arguments when set.
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	usage := "Usage: " + strings.Join(e.Usages, "\nor\n")
+	if e.Err != nil {
+		return e.Err.Error() + usage
+	}
+	return fmt.Sprintf("could not execute %s.\n%s", e.Command, usage)
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// FatalError reports a failure that stops Run, such as an I/O error on its
+// reader, its writer or its storage. It is sent to the errors channel of Run.
+type FatalError struct {
+	Err error
+}
+
+func (e *FatalError) Error() string {
+	return fmt.Sprintf("task list stopped: %v", e.Err)
+}
+
+func (e *FatalError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+// maxHistory is the number of commands that can be undone.
+const maxHistory = 50
+
+// historyEntry is a command that modified the TaskList, with the content
+// of the TaskList before and after it.
+type historyEntry struct {
+	cmdLine string
+	before  tasklist.Snapshot
+	after   tasklist.Snapshot
+}
+
+// remember adds a command to the ones that can be undone, forgetting the
+// oldest one past maxHistory and the ones that could be redone.
+func (l *TaskListReaderWriter) remember(entry historyEntry) {
+	l.undoHistory = append(l.undoHistory, entry)
+	if len(l.undoHistory) > maxHistory {
+		l.undoHistory = l.undoHistory[len(l.undoHistory)-maxHistory:]
+	}
+	l.redoHistory = nil
+}
+
+func (l *TaskListReaderWriter) undo(w io.Writer, _ []string) error {
+	if len(l.undoHistory) == 0 {
+		fmt.Fprintln(w, "Nothing to undo.")
+		return nil
+	}
+
+	entry := l.undoHistory[len(l.undoHistory)-1]
+	if err := l.taskList.Restore(entry.before); err != nil {
+		return err
+	}
+	l.undoHistory = l.undoHistory[:len(l.undoHistory)-1]
+	l.redoHistory = append(l.redoHistory, entry)
+
+	fmt.Fprintf(w, "Undone: %s\n", entry.cmdLine)
+	return nil
+}
+
+func (l *TaskListReaderWriter) redo(w io.Writer, _ []string) error {
+	if len(l.redoHistory) == 0 {
+		fmt.Fprintln(w, "Nothing to redo.")
+		return nil
+	}
+
+	entry := l.redoHistory[len(l.redoHistory)-1]
+	if err := l.taskList.Restore(entry.after); err != nil {
+		return err
+	}
+	l.redoHistory = l.redoHistory[:len(l.redoHistory)-1]
+	l.undoHistory = append(l.undoHistory, entry)
+
+	fmt.Fprintf(w, "Redone: %s\n", entry.cmdLine)
+	return nil
+}
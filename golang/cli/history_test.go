@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTaskListReaderWriter_undoAndRedo(t *testing.T) {
+	l, out := newTestTaskListReaderWriter(strings.Join([]string{
+		"undo",
+		"add project secrets",
+		"add task secrets Eat more donuts.",
+		"add task secrets Destroy all humans.",
+		"check 1",
+		"delete 2",
+		"show",
+		"undo",
+		"undo",
+		"show",
+		"redo",
+		"show",
+		"redo",
+		"redo",
+		"undo",
+		"deadline 1 2024-05-01",
+		"redo",
+		"show",
+	}, "\n"))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		"> Nothing to undo.",
+		"> > > > > > secrets",
+		"    [X] 1: Eat more donuts.",
+		"",
+		"> Undone: delete 2",
+		"> Undone: check 1",
+		"> secrets",
+		"    [ ] 1: Eat more donuts.",
+		"    [ ] 2: Destroy all humans.",
+		"",
+		"> Redone: check 1",
+		"> secrets",
+		"    [X] 1: Eat more donuts.",
+		"    [ ] 2: Destroy all humans.",
+		"",
+		"> Redone: delete 2",
+		"> Nothing to redo.",
+		"> Undone: delete 2",
+		"> > Nothing to redo.",
+		"> secrets",
+		"    [X] 1: (2024-05-01) Eat more donuts.",
+		"    [ ] 2: Destroy all humans.",
+		"",
+		"> ",
+	}, "\n")
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
+
+func TestTaskListReaderWriter_undoHistoryIsBounded(t *testing.T) {
+	var commands []string
+	commands = append(commands, "add project secrets")
+	for i := 0; i < maxHistory; i++ {
+		commands = append(commands, "add task secrets Eat more donuts.")
+	}
+	for i := 0; i <= maxHistory; i++ {
+		commands = append(commands, "undo")
+	}
+	l, out := newTestTaskListReaderWriter(strings.Join(commands, "\n"))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasSuffix(out.String(), "> Nothing to undo.\n> ") {
+		t.Errorf("expected the history to be exhausted, got %q", out.String())
+	}
+	projects := l.taskList.GetProjectsWithTasks()
+	if len(projects) != 1 || len(projects[0].Tasks) != 0 {
+		t.Errorf("expected the project to be kept with no tasks, got %+v", projects)
+	}
+}
+
+func TestTaskListReaderWriter_undoSkipsCommandsChangingNothing(t *testing.T) {
+	l, out := newTestTaskListReaderWriter(strings.Join([]string{
+		"add project secrets",
+		"add task secrets Eat more donuts.",
+		"add task secrets Destroy all humans.",
+		"depends 2 on 1",
+		"move 1 secrets",
+		"depends 2 on 1",
+		"undo",
+		"undo",
+		"",
+	}, "\n"))
+
+	if err := runToEnd(l); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Repeat("> ", 7) + "Undone: depends 2 on 1\n> Undone: add task secrets Destroy all humans.\n> "
+	if out.String() != want {
+		t.Errorf("expected %q, got %q", want, out.String())
+	}
+}
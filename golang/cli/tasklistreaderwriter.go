@@ -0,0 +1,872 @@
+// Package cli implements the command line of the task manager on top of
+// package tasklist. A manager is a TaskListReaderWriter object, which is
+// started with the Run() function and then scans and executes user commands.
+// Programs embedding it can add their own commands with Register.
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+const (
+	// Quit is the text command used to quit the task manager.
+	quit   string = "quit"
+	prompt string = "> "
+
+	showCommand     = "show"
+	viewCommand     = "view"
+	addCommand      = "add"
+	checkCommand    = "check"
+	uncheckCommand  = "uncheck"
+	helpCommand     = "help"
+	deadlineCommand = "deadline"
+	repeatCommand   = "repeat"
+	priorityCommand = "priority"
+	tagCommand      = "tag"
+	untagCommand    = "untag"
+	todayCommand    = "today"
+	overdueCommand  = "overdue"
+	upcomingCommand = "upcoming"
+	deleteCommand   = "delete"
+	editCommand     = "edit"
+	moveCommand     = "move"
+	renameCommand   = "rename"
+	undoCommand     = "undo"
+	redoCommand     = "redo"
+	searchCommand   = "search"
+	dependsCommand  = "depends"
+	blockedCommand  = "blocked"
+	nextCommand     = "next"
+
+	forceFlag = "--force"
+	allFlag   = "--all"
+	regexFlag = "--regex"
+	fuzzyFlag = "--fuzzy"
+
+	viewByProject  = "project"
+	viewByDeadline = "deadline"
+	viewByDate     = "date"
+	viewByPriority = "priority"
+	viewByTag      = "tag"
+
+	noDeadlineHeader = "No deadline"
+	noTagHeader      = "No tag"
+
+	// taskIndent is the indentation of the tasks under their header, and of
+	// the subtasks under their parent.
+	taskIndent = "    "
+)
+
+// TaskListReaderWriter wraps a TaskList with read and write capabilities.
+type TaskListReaderWriter struct {
+	r        io.Reader
+	w        *errWriter
+	taskList *tasklist.TaskList
+	storage  tasklist.Storage
+
+	commands       []*Command
+	commandsByName map[string]*Command
+
+	// undoHistory and redoHistory hold the commands that can be undone and
+	// redone, the most recent last.
+	undoHistory []historyEntry
+	redoHistory []historyEntry
+}
+
+// errWriter remembers the first error of the wrapped writer, so that Run can
+// detect output failures once per command instead of on every write.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	n, err := w.w.Write(p)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// NewTaskListReaderWriter initializes a TaskList on the given reader and writer,
+// configured with options.
+func NewTaskListReaderWriter(r io.Reader, w io.Writer, idGenerator func(id int64) string, options ...tasklist.Option) *TaskListReaderWriter {
+	l := &TaskListReaderWriter{
+		r:              r,
+		w:              &errWriter{w: w},
+		taskList:       tasklist.NewTaskList(idGenerator, options...),
+		commandsByName: make(map[string]*Command),
+	}
+	l.registerBuiltinCommands()
+
+	return l
+}
+
+// NewTaskListReaderWriterWithStorage initializes a TaskList on the given reader and writer,
+// loading its content from storage and saving it back after every command that modifies it.
+func NewTaskListReaderWriterWithStorage(r io.Reader, w io.Writer, idGenerator func(id int64) string, storage tasklist.Storage, options ...tasklist.Option) (*TaskListReaderWriter, error) {
+	l := NewTaskListReaderWriter(r, w, idGenerator, options...)
+	if err := storage.Load(l.taskList); err != nil {
+		return nil, err
+	}
+	l.storage = storage
+
+	return l, nil
+}
+
+// TaskList returns the TaskList the commands read and modify, for the
+// commands added with Register.
+func (l *TaskListReaderWriter) TaskList() *tasklist.TaskList {
+	return l.taskList
+}
+
+// registerBuiltinCommands registers the commands of the task manager, in
+// the order they are listed by help.
+func (l *TaskListReaderWriter) registerBuiltinCommands() {
+	builtins := []Command{
+		{
+			Name:    showCommand,
+			Usages:  []string{"show [<query>]"},
+			MaxArgs: -1,
+			Run:     l.show,
+		},
+		{
+			Name:    viewCommand,
+			Usages:  []string{"view by " + viewByProject + " [<query>]", "view by " + viewByDeadline + " [<query>]", "view by " + viewByDate + " [<query>]", "view by " + viewByPriority + " [<query>]", "view by " + viewByTag + " [<query>]"},
+			MinArgs: 2,
+			MaxArgs: -1,
+			Run:     l.view,
+		},
+		{
+			Name:     addCommand,
+			Usages:   []string{"add project <project name>", "add task <project name> <task description>", "add task(<task ID>) <project name> <task description>", "add task <project name> <task description> !high|!medium|!low #<tag>", "add subtask <parent task ID> <task description>"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.add,
+		},
+		{
+			Name:     checkCommand,
+			Usages:   []string{"check <task ID> [" + forceFlag + "]"},
+			MinArgs:  1,
+			MaxArgs:  2,
+			Mutating: true,
+			Run:      l.check,
+		},
+		{
+			Name:     uncheckCommand,
+			Usages:   []string{"uncheck <task ID>"},
+			MinArgs:  1,
+			MaxArgs:  1,
+			Mutating: true,
+			Run:      l.uncheck,
+		},
+		{
+			Name:     deadlineCommand,
+			Usages:   []string{"deadline <task ID> <date>[T<HH:MM> [<time zone>]]", "deadline <task ID> none"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.deadline,
+		},
+		{
+			Name:     repeatCommand,
+			Usages:   []string{"repeat <task ID> every monday|every 2 weeks|monthly on 1", "repeat <task ID> none"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.repeat,
+		},
+		{
+			Name:     priorityCommand,
+			Usages:   []string{"priority <task ID> high|medium|low|none"},
+			MinArgs:  2,
+			MaxArgs:  2,
+			Mutating: true,
+			Run:      l.priority,
+		},
+		{
+			Name:     tagCommand,
+			Usages:   []string{"tag <task ID> +<tag> [+<tag>...]"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.tag,
+		},
+		{
+			Name:     untagCommand,
+			Usages:   []string{"untag <task ID> +<tag> [+<tag>...]"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.untag,
+		},
+		{
+			Name:     dependsCommand,
+			Usages:   []string{"depends <task ID> on <task ID>"},
+			MinArgs:  3,
+			MaxArgs:  3,
+			Mutating: true,
+			Run:      l.depends,
+		},
+		{
+			Name:     editCommand,
+			Usages:   []string{"edit <task ID> <task description>"},
+			MinArgs:  2,
+			MaxArgs:  -1,
+			Mutating: true,
+			Run:      l.edit,
+		},
+		{
+			Name:     moveCommand,
+			Usages:   []string{"move <task ID> <project name>"},
+			MinArgs:  2,
+			MaxArgs:  2,
+			Mutating: true,
+			Run:      l.move,
+		},
+		{
+			Name:     renameCommand,
+			Usages:   []string{"rename project <project name> <new project name>"},
+			MinArgs:  3,
+			MaxArgs:  3,
+			Mutating: true,
+			Run:      l.rename,
+		},
+		{
+			Name:     deleteCommand,
+			Usages:   []string{"delete <task ID> [" + forceFlag + "]", "delete project <project name> [" + forceFlag + "]"},
+			MinArgs:  1,
+			MaxArgs:  3,
+			Mutating: true,
+			Run:      l.delete,
+		},
+		{
+			Name:     undoCommand,
+			Usages:   []string{"undo"},
+			MaxArgs:  0,
+			Mutating: true,
+			Run:      l.undo,
+		},
+		{
+			Name:     redoCommand,
+			Usages:   []string{"redo"},
+			MaxArgs:  0,
+			Mutating: true,
+			Run:      l.redo,
+		},
+		{
+			Name:    todayCommand,
+			Usages:  []string{"today [" + allFlag + "] [<query>]"},
+			MaxArgs: -1,
+			Run:     l.today,
+		},
+		{
+			Name:    overdueCommand,
+			Usages:  []string{"overdue [" + allFlag + "] [<query>]"},
+			MaxArgs: -1,
+			Run:     l.overdue,
+		},
+		{
+			Name:    upcomingCommand,
+			Usages:  []string{"upcoming [<number of days> days] [" + allFlag + "] [<query>]"},
+			MaxArgs: -1,
+			Run:     l.upcoming,
+		},
+		{
+			Name:    blockedCommand,
+			Usages:  []string{"blocked [<query>]"},
+			MaxArgs: -1,
+			Run:     l.blocked,
+		},
+		{
+			Name:    nextCommand,
+			Usages:  []string{"next [<query>]"},
+			MaxArgs: -1,
+			Run:     l.next,
+		},
+		{
+			Name:    searchCommand,
+			Usages:  []string{"search [" + regexFlag + "|" + fuzzyFlag + "] <term> [<term>...]"},
+			MinArgs: 1,
+			MaxArgs: -1,
+			Run:     l.search,
+		},
+		{
+			Name:    helpCommand,
+			Usages:  []string{"help"},
+			MaxArgs: 0,
+			Run:     l.help,
+		},
+	}
+
+	for _, command := range builtins {
+		if err := l.Register(command); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Run runs the command loop of the task manager.
+// Sequentially executes any given command, until the user types the Quit message
+// or the reader is exhausted, which is signaled on shutdownChan.
+// Invalid commands are reported on the writer and do not stop the loop; only
+// a *FatalError, sent on errorsChan, does.
+func (l *TaskListReaderWriter) Run(errorsChan chan<- error, shutdownChan chan bool) {
+	scanner := bufio.NewScanner(l.r)
+
+	fmt.Fprint(l.w, prompt)
+	for l.w.err == nil && scanner.Scan() {
+		cmdLine := scanner.Text()
+		if cmdLine == quit {
+			shutdownChan <- true
+			return
+		}
+
+		err := l.execute(cmdLine)
+		var fatalErr *FatalError
+		if errors.As(err, &fatalErr) {
+			errorsChan <- err
+			return
+		}
+		if err != nil {
+			fmt.Fprintln(l.w, err)
+		}
+		fmt.Fprint(l.w, prompt)
+	}
+
+	if l.w.err != nil {
+		errorsChan <- &FatalError{Err: l.w.err}
+		return
+	}
+	if err := scanner.Err(); err != nil {
+		errorsChan <- &FatalError{Err: err}
+		return
+	}
+	shutdownChan <- true
+}
+
+func (l *TaskListReaderWriter) execute(cmdLine string) error {
+	args, err := tokenize(cmdLine)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	command, ok := l.commandsByName[args[0]]
+	if !ok {
+		return tasklist.NewError(ErrUnknownCommand, "Unknown command \"%s\".\n", args[0])
+	}
+
+	undoable := command.Mutating && command.Name != undoCommand && command.Name != redoCommand
+	var before tasklist.Snapshot
+	if undoable {
+		before = l.taskList.Snapshot()
+	}
+
+	if err := command.execute(l.w, args[1:]); err != nil {
+		if errors.Is(err, tasklist.ErrJournal) {
+			return &FatalError{Err: err}
+		}
+		return err
+	}
+	if undoable {
+		// A command changing nothing, like moving a task to its own project,
+		// leaves nothing to undo.
+		if after := l.taskList.Snapshot(); !before.Equal(after) {
+			l.remember(historyEntry{
+				cmdLine: strings.TrimSpace(cmdLine),
+				before:  before,
+				after:   after,
+			})
+		}
+	}
+	if command.Mutating {
+		return l.save()
+	}
+	return nil
+}
+
+// save persists the TaskList when a storage is configured.
+func (l *TaskListReaderWriter) save() error {
+	if l.storage == nil {
+		return nil
+	}
+
+	if err := l.storage.Save(l.taskList); err != nil {
+		return &FatalError{Err: err}
+	}
+	return nil
+}
+
+func (l *TaskListReaderWriter) help(w io.Writer, _ []string) error {
+	fmt.Fprintln(w, l.helpMessage())
+	return nil
+}
+
+func (l *TaskListReaderWriter) today(w io.Writer, args []string) error {
+	args, includeDone := cutFlag(args, allFlag)
+	taskList, err := l.filtered(args)
+	if err != nil {
+		return err
+	}
+
+	writeProjectsWithTasks(w, taskList.GetProjectsWithTasksDueToday(includeDone))
+	return nil
+}
+
+func (l *TaskListReaderWriter) overdue(w io.Writer, args []string) error {
+	args, includeDone := cutFlag(args, allFlag)
+	taskList, err := l.filtered(args)
+	if err != nil {
+		return err
+	}
+
+	writeProjectsWithTasks(w, taskList.GetProjectsWithOverdueTasks(includeDone))
+	return nil
+}
+
+func (l *TaskListReaderWriter) upcoming(w io.Writer, args []string) error {
+	args, includeDone := cutFlag(args, allFlag)
+	days := tasklist.DefaultUpcomingDays
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			if n <= 0 {
+				return ErrUsage
+			}
+			days = n
+			args = args[1:]
+			if len(args) > 0 && (args[0] == "days" || args[0] == "day") {
+				args = args[1:]
+			}
+		}
+	}
+	taskList, err := l.filtered(args)
+	if err != nil {
+		return err
+	}
+
+	writeProjectsWithTasks(w, taskList.GetProjectsWithUpcomingTasks(days, includeDone))
+	return nil
+}
+
+func (l *TaskListReaderWriter) search(w io.Writer, args []string) error {
+	args, regex := cutFlag(args, regexFlag)
+	args, fuzzy := cutFlag(args, fuzzyFlag)
+	if len(args) == 0 || (regex && fuzzy) {
+		return ErrUsage
+	}
+	mode := tasklist.SearchText
+	switch {
+	case regex:
+		mode = tasklist.SearchRegexp
+	case fuzzy:
+		mode = tasklist.SearchFuzzy
+	}
+
+	results, err := l.taskList.Search(args, mode)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No task found.")
+		return nil
+	}
+	for _, result := range results {
+		fmt.Fprintf(w, "%s: %s\n", result.ProjectName, formatTask(result.Task))
+	}
+	return nil
+}
+
+// cutFlag returns args without flag, and whether flag was among them.
+func cutFlag(args []string, flag string) ([]string, bool) {
+	found := false
+	rest := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// filtered returns the TaskList restricted to the tasks selected by query,
+// or the whole TaskList without a query.
+func (l *TaskListReaderWriter) filtered(query []string) (*tasklist.TaskList, error) {
+	if len(query) == 0 {
+		return l.taskList, nil
+	}
+
+	q, err := l.taskList.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return l.taskList.Filter(q), nil
+}
+
+func (l *TaskListReaderWriter) show(w io.Writer, args []string) error {
+	taskList, err := l.filtered(args)
+	if err != nil {
+		return err
+	}
+
+	writeProjectsWithTasks(w, taskList.GetProjectsWithTasks())
+	return nil
+}
+
+func (l *TaskListReaderWriter) view(w io.Writer, args []string) error {
+	if args[0] != "by" {
+		return ErrUsage
+	}
+	switch args[1] {
+	case viewByProject, viewByDeadline, viewByDate, viewByPriority, viewByTag:
+	default:
+		return ErrUsage
+	}
+	taskList, err := l.filtered(args[2:])
+	if err != nil {
+		return err
+	}
+
+	switch args[1] {
+	case viewByProject:
+		writeProjectsWithTasks(w, taskList.GetProjectsWithTasks())
+	case viewByDeadline:
+		writeDatesWithTasks(w, taskList.GetDeadlinesWithTasks(), noDeadlineHeader)
+	case viewByDate:
+		writeDatesWithTasks(w, taskList.GetCreationDatesWithTasks(), "Unknown date")
+	case viewByPriority:
+		writeProjectsWithTasks(w, taskList.GetProjectsWithTasksByPriority())
+	case viewByTag:
+		writeTagsWithTasks(w, taskList.GetTagsWithTasks())
+	}
+	return nil
+}
+
+// writeProjectsWithTasks writes every project followed by its tasks.
+func writeProjectsWithTasks(w io.Writer, projectsWithTasks []tasklist.ProjectWithTasks) {
+	for _, projectWithTasks := range projectsWithTasks {
+		fmt.Fprintf(w, "%s\n", projectWithTasks.ProjectName)
+		writeTasks(w, projectWithTasks.Tasks)
+		fmt.Fprintln(w)
+	}
+}
+
+// writeTagsWithTasks writes every tag followed by its tasks.
+func writeTagsWithTasks(w io.Writer, tagsWithTasks []tasklist.TagWithTasks) {
+	for _, tagWithTasks := range tagsWithTasks {
+		header := noTagHeader
+		if tagWithTasks.Tag != "" {
+			header = "#" + string(tagWithTasks.Tag)
+		}
+		fmt.Fprintln(w, header)
+		writeTasks(w, tagWithTasks.Tasks)
+		fmt.Fprintln(w)
+	}
+}
+
+// writeDatesWithTasks writes every date followed by its tasks, using
+// emptyDateHeader for the tasks without a date.
+func writeDatesWithTasks(w io.Writer, datesWithTasks []tasklist.DateWithTasks, emptyDateHeader string) {
+	for _, dateWithTasks := range datesWithTasks {
+		header := emptyDateHeader
+		if !dateWithTasks.Date.IsZero() {
+			header = dateWithTasks.Date.Format(time.DateOnly)
+		}
+		fmt.Fprintf(w, "%s\n", header)
+		writeTasks(w, dateWithTasks.Tasks)
+		fmt.Fprintln(w)
+	}
+}
+
+// writeTasks writes the info of the tasks to the writer w, the subtasks
+// indented under their parent when it is listed too.
+func writeTasks(w io.Writer, tasks []*tasklist.Task) {
+	listed := make(map[tasklist.Identifier]bool, len(tasks))
+	for _, task := range tasks {
+		listed[task.GetID()] = true
+	}
+	var topLevel []*tasklist.Task
+	subtasks := make(map[tasklist.Identifier][]*tasklist.Task)
+	for _, task := range tasks {
+		if parent := task.GetParent(); listed[parent] {
+			subtasks[parent] = append(subtasks[parent], task)
+		} else {
+			topLevel = append(topLevel, task)
+		}
+	}
+
+	var write func(task *tasklist.Task, indent string)
+	write = func(task *tasklist.Task, indent string) {
+		fmt.Fprintf(w, "%s%s\n", indent, formatTask(task))
+		for _, subtask := range subtasks[task.GetID()] {
+			write(subtask, indent+taskIndent)
+		}
+	}
+	for _, task := range topLevel {
+		write(task, taskIndent)
+	}
+}
+
+// formatTask returns the task info as written by writeTasks, without indentation.
+func formatTask(task *tasklist.Task) string {
+	doneChar := ' '
+	if task.IsDone() {
+		doneChar = 'X'
+	}
+	var schedule []string
+	if d := task.GetDeadline(); !d.IsEmpty() {
+		schedule = append(schedule, formatDeadline(d))
+	}
+	if r := task.GetRecurrence(); !r.IsEmpty() {
+		schedule = append(schedule, r.String())
+	}
+	deadline := ""
+	if len(schedule) > 0 {
+		deadline = fmt.Sprintf(" (%s)", strings.Join(schedule, ", "))
+	}
+	tags := ""
+	for _, tag := range task.GetTags() {
+		tags += " #" + string(tag)
+	}
+	priority := ""
+	if p := task.GetPriority(); p != tasklist.PriorityNone {
+		priority = " !" + p.String()
+	}
+	return fmt.Sprintf("[%c] %v:%s %s%s%s", doneChar, task.GetID(), deadline, task.GetDescription(), tags, priority)
+}
+
+// formatDeadline returns the day of the deadline, followed by its time of
+// day, already in the time zone of the TaskList, when it has one.
+func formatDeadline(d tasklist.Deadline) string {
+	if d.HasTime() {
+		return d.Time().Format("2006-01-02 15:04")
+	}
+	return d.String()
+}
+
+func (l *TaskListReaderWriter) add(_ io.Writer, args []string) error {
+	projectName := args[1]
+	if args[0] == "project" {
+		// A name with spaces must be quoted, rather than cut at the first space.
+		if len(args) > 2 {
+			return ErrUsage
+		}
+		return l.taskList.AddProject(projectName)
+	}
+
+	taskSubcommand := args[0]
+	if strings.HasPrefix(taskSubcommand, "task(") && !strings.HasSuffix(taskSubcommand, ")") {
+		// The ID ends at the first space, which IDs cannot contain.
+		return withUsage(tasklist.NewError(ErrInvalidCommandLine, "\"%s\" is missing its closing parenthesis, task IDs cannot contain spaces.\n", taskSubcommand))
+	}
+	if !(strings.HasPrefix(taskSubcommand, "task") || taskSubcommand == "subtask") || len(args) < 3 {
+		return ErrUsage
+	}
+
+	description := strings.Join(args[2:], " ")
+	if taskSubcommand == "subtask" {
+		_, err := l.taskList.AddSubtask(args[1], description)
+		return err
+	}
+	if taskSubcommand == "task" {
+		_, err := l.taskList.AddTaskToProject(projectName, description)
+		return err
+	}
+
+	s := `^task\((.*)\)$`
+	r := regexp.MustCompile(s)
+	submatches := r.FindStringSubmatch(taskSubcommand)
+	if len(submatches) < 2 {
+		return ErrUsage
+	}
+	taskId := submatches[1]
+	_, err := l.taskList.AddTaskToProjectWithID(taskId, projectName, description)
+	return err
+}
+
+func (l *TaskListReaderWriter) check(w io.Writer, args []string) error {
+	args, force := cutFlag(args, forceFlag)
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	blockers, err := l.taskList.GetBlockers(args[0])
+	if err != nil {
+		return err
+	}
+	next, err := l.taskList.Check(args[0], force)
+	if errors.Is(err, tasklist.ErrOpenSubtasks) {
+		return withUsage(err)
+	}
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		fmt.Fprintf(w, "Warning: task \"%s\" was still waiting on %s.\n", args[0], joinIDs(blockers))
+	}
+	if next != nil {
+		fmt.Fprintf(w, "Next occurrence: task %s due %s.\n", next.GetID(), formatDeadline(next.GetDeadline()))
+	}
+	return nil
+}
+
+func (l *TaskListReaderWriter) depends(_ io.Writer, args []string) error {
+	if args[1] != "on" {
+		return ErrUsage
+	}
+	return l.taskList.AddDependency(args[0], args[2])
+}
+
+func (l *TaskListReaderWriter) blocked(w io.Writer, args []string) error {
+	projectsWithTasks, err := l.selected(l.taskList.GetProjectsWithBlockedTasks(), args)
+	if err != nil {
+		return err
+	}
+
+	for _, projectWithTasks := range projectsWithTasks {
+		fmt.Fprintf(w, "%s\n", projectWithTasks.ProjectName)
+		for _, task := range projectWithTasks.Tasks {
+			blockers, err := l.taskList.GetBlockers(string(task.GetID()))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s%s (waiting on %s)\n", taskIndent, formatTask(task), joinIDs(blockers))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func (l *TaskListReaderWriter) next(w io.Writer, args []string) error {
+	projectsWithTasks, err := l.selected(l.taskList.GetProjectsWithNextTasks(), args)
+	if err != nil {
+		return err
+	}
+
+	writeProjectsWithTasks(w, projectsWithTasks)
+	return nil
+}
+
+// selected returns the projects with only their tasks selected by query,
+// leaving out the projects without any, or all of them without a query.
+// Unlike filtered, it keeps the views computed over the whole TaskList.
+func (l *TaskListReaderWriter) selected(projectsWithTasks []tasklist.ProjectWithTasks, query []string) ([]tasklist.ProjectWithTasks, error) {
+	if len(query) == 0 {
+		return projectsWithTasks, nil
+	}
+
+	q, err := l.taskList.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	var selected []tasklist.ProjectWithTasks
+	for _, projectWithTasks := range projectsWithTasks {
+		var tasks []*tasklist.Task
+		for _, task := range projectWithTasks.Tasks {
+			if q.Matches(task, projectWithTasks.ProjectName) {
+				tasks = append(tasks, task)
+			}
+		}
+		if len(tasks) > 0 {
+			selected = append(selected, tasklist.ProjectWithTasks{ProjectName: projectWithTasks.ProjectName, Tasks: tasks})
+		}
+	}
+	return selected, nil
+}
+
+// joinIDs returns the task IDs separated by commas.
+func joinIDs(ids []tasklist.Identifier) string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, string(id))
+	}
+	return strings.Join(strs, ", ")
+}
+
+func (l *TaskListReaderWriter) uncheck(_ io.Writer, args []string) error {
+	return l.taskList.Uncheck(args[0])
+}
+
+func (l *TaskListReaderWriter) deadline(_ io.Writer, args []string) error {
+	return l.taskList.SetDeadline(args[0], strings.Join(args[1:], " "))
+}
+
+func (l *TaskListReaderWriter) repeat(_ io.Writer, args []string) error {
+	return l.taskList.SetRecurrence(args[0], strings.Join(args[1:], " "))
+}
+
+func (l *TaskListReaderWriter) priority(_ io.Writer, args []string) error {
+	return l.taskList.SetPriority(args[0], args[1])
+}
+
+func (l *TaskListReaderWriter) tag(_ io.Writer, args []string) error {
+	return l.taskList.TagTask(args[0], args[1:]...)
+}
+
+func (l *TaskListReaderWriter) untag(_ io.Writer, args []string) error {
+	return l.taskList.UntagTask(args[0], args[1:]...)
+}
+
+func (l *TaskListReaderWriter) edit(_ io.Writer, args []string) error {
+	return l.taskList.EditTask(args[0], strings.Join(args[1:], " "))
+}
+
+func (l *TaskListReaderWriter) move(_ io.Writer, args []string) error {
+	return l.taskList.MoveTask(args[0], args[1])
+}
+
+func (l *TaskListReaderWriter) rename(_ io.Writer, args []string) error {
+	if args[0] != "project" {
+		return ErrUsage
+	}
+	return l.taskList.RenameProject(args[1], args[2])
+}
+
+func (l *TaskListReaderWriter) delete(_ io.Writer, args []string) error {
+	if args[0] != "project" {
+		args, force := cutFlag(args, forceFlag)
+		if len(args) != 1 {
+			return ErrUsage
+		}
+		return forceUsage(l.taskList.DeleteTask(args[0], force))
+	}
+
+	if len(args) < 2 {
+		return ErrUsage
+	}
+	force := false
+	if len(args) > 2 {
+		if args[2] != forceFlag {
+			return ErrUsage
+		}
+		force = true
+	}
+	return forceUsage(l.taskList.DeleteProject(args[1], force))
+}
+
+// forceUsage reports the deletions refused without --force along with the
+// usages of delete, which show the flag.
+func forceUsage(err error) error {
+	if errors.Is(err, tasklist.ErrTaskHasSubtasks) || errors.Is(err, tasklist.ErrProjectNotEmpty) {
+		return withUsage(err)
+	}
+	return err
+}
@@ -0,0 +1,879 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+type scenarioTester struct {
+	*testing.T
+
+	inWriter   io.Writer
+	outReader  io.Reader
+	outScanner *bufio.Scanner
+}
+
+// TODO: Make use of this struct in all tests
+type TaskListRunParams struct {
+	inPR         *io.PipeReader
+	inPW         *io.PipeWriter
+	outPW        *io.PipeWriter
+	outPR        *io.PipeReader
+	errorsChan   chan error
+	shutdownChan chan bool
+}
+
+func NewTaskListRunParams() TaskListRunParams {
+	inPR, inPW := io.Pipe()
+	outPR, outPW := io.Pipe()
+	return TaskListRunParams{
+		inPR:         inPR,
+		inPW:         inPW,
+		outPW:        outPW,
+		outPR:        outPR,
+		errorsChan:   make(chan error),
+		shutdownChan: make(chan bool),
+	}
+}
+
+func TestTaskList_executeWithErrors(t *testing.T) {
+	type args struct {
+		cmdCommands []string
+	}
+
+	type testData struct {
+		name      string
+		args      args
+		readLines []string
+	}
+
+	tests := []testData{
+		{
+			name: "test deadline without more parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"deadline"},
+			},
+			readLines: []string{"could not execute deadline.", "Usage: deadline <task ID> <date>[T<HH:MM> [<time zone>]]", "or", "deadline <task ID> none"},
+		},
+		{
+			name: "test deadline without a date prints its usage",
+			args: args{
+				cmdCommands: []string{"deadline 1"},
+			},
+			readLines: []string{"could not execute deadline.", "Usage: deadline <task ID> <date>[T<HH:MM> [<time zone>]]", "or", "deadline <task ID> none"},
+		},
+		{
+			name: "test add without more parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"add"},
+			},
+			readLines: []string{"could not execute add.", "Usage: add project <project name>", "or", "add task <project name> <task description>", "or", "add task(<task ID>) <project name> <task description>"},
+		},
+		{
+			name: "test add without two parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"add foo"},
+			},
+			readLines: []string{"could not execute add.", "Usage: add project <project name>", "or", "add task <project name> <task description>", "or", "add task(<task ID>) <project name> <task description>"},
+		},
+		{
+			name: "test check without more parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"check"},
+			},
+			readLines: []string{"could not execute check.", "Usage: check <task ID> [--force]"},
+		},
+		{
+			name: "test uncheck without more parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"uncheck"},
+			},
+			readLines: []string{"could not execute uncheck.", "Usage: uncheck <task ID>"},
+		},
+		{
+			name: "test delete without more parameters prints its usage",
+			args: args{
+				cmdCommands: []string{"delete"},
+			},
+			readLines: []string{"could not execute delete.", "Usage: delete <task ID> [--force]", "or", "delete project <project name> [--force]"},
+		},
+		{
+			name: "test unterminated quote prints the error",
+			args: args{
+				cmdCommands: []string{`add project "Q3 launch`},
+			},
+			readLines: []string{"missing closing \" in command line.", ""},
+		},
+		{
+			name: "test check of an unknown task prints the error",
+			args: args{
+				cmdCommands: []string{"check 1"},
+			},
+			readLines: []string{"task with ID \"1\" not found.", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runParams := NewTaskListRunParams()
+			defer runParams.inPR.Close()
+			defer runParams.outPR.Close()
+			defer close(runParams.shutdownChan)
+			defer close(runParams.errorsChan)
+
+			tester := &scenarioTester{
+				T:          t,
+				inWriter:   runParams.inPW,
+				outReader:  runParams.outPR,
+				outScanner: bufio.NewScanner(runParams.outPR),
+			}
+			initTaskListAndRun(runParams.inPR, runParams.outPW, runParams.errorsChan, runParams.shutdownChan)
+
+			for _, command := range tt.args.cmdCommands {
+				log.Println(tt.name)
+				tester.execute(command)
+			}
+
+			tester.readLines(tt.readLines)
+			tester.execute("quit")
+
+			runParams.inPW.Close()
+
+			select {
+			case err := <-runParams.errorsChan:
+				t.Errorf("expected the session to go on after the error, got %v", err)
+			case <-runParams.shutdownChan:
+			}
+		})
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write(_ []byte) (int, error) {
+	return 0, errors.New("broken output")
+}
+
+func TestTaskList_RunWithFatalErrors(t *testing.T) {
+	idGenerator := func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}
+
+	t.Run("failing writer stops Run with a FatalError", func(t *testing.T) {
+		errorsChan := make(chan error, 1)
+		shutdownChan := make(chan bool, 1)
+
+		NewTaskListReaderWriter(strings.NewReader("show\n"), failingWriter{}, idGenerator).Run(errorsChan, shutdownChan)
+
+		select {
+		case err := <-errorsChan:
+			var fatalErr *FatalError
+			if !errors.As(err, &fatalErr) {
+				t.Errorf("expected a *FatalError, got %v", err)
+			}
+		default:
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("failing storage stops Run with a FatalError", func(t *testing.T) {
+		errorsChan := make(chan error, 1)
+		shutdownChan := make(chan bool, 1)
+		storage := tasklist.NewFileStorage(filepath.Join(t.TempDir(), "missing", "tasks.json"))
+
+		taskList, err := NewTaskListReaderWriterWithStorage(strings.NewReader("add project secrets\n"), io.Discard, idGenerator, storage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		taskList.Run(errorsChan, shutdownChan)
+
+		select {
+		case err := <-errorsChan:
+			if !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("expected the storage error to be reachable, got %v", err)
+			}
+		default:
+			t.Errorf("expected an error")
+		}
+	})
+
+	t.Run("end of input shuts down", func(t *testing.T) {
+		errorsChan := make(chan error, 1)
+		shutdownChan := make(chan bool, 1)
+
+		NewTaskListReaderWriter(strings.NewReader("check\n"), io.Discard, idGenerator).Run(errorsChan, shutdownChan)
+
+		select {
+		case err := <-errorsChan:
+			t.Errorf("expected no error, got %v", err)
+		case <-shutdownChan:
+		}
+	})
+}
+
+func TestTaskList_executeWithReadLines(t *testing.T) {
+	type args struct {
+		cmdCommands []string
+	}
+
+	type testData struct {
+		name      string
+		args      args
+		readLines []string
+	}
+	tests := []testData{
+		{
+			name: "after executing run, check and show commands, list of both checked and pending tasks is returned",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "add project training", "add task training Four Elements of Simple Design", "add task training SOLID", "add task training Coupling and Cohesion", "add task training Primitive Obsession", "add task training Outside-In TDD", "add task training Interaction-Driven Design", "check 1", "check 3", "check 5", "check 6", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [X] 1: Eat more donuts.",
+				"    [ ] 2: Destroy all humans.",
+				"",
+				"training",
+				"    [X] 3: Four Elements of Simple Design",
+				"    [ ] 4: SOLID",
+				"    [X] 5: Coupling and Cohesion",
+				"    [X] 6: Primitive Obsession",
+				"    [ ] 7: Outside-In TDD",
+				"    [ ] 8: Interaction-Driven Design",
+				"",
+			},
+		},
+		{
+			name: "after executing run, deadline and show commands, list of pending tasks with deadlines is returned",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "deadline 1 2020-07-21", "deadline 2 2020-07-30", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: (2020-07-21) Eat more donuts.",
+				"    [ ] 2: (2020-07-30) Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "Custom IDs are working after inserting some Tasks to Projects",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(12345) secrets Destroy all humans.", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 12345: Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "Custom IDs can be used by every command",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(abc) secrets Eat more donuts.", "add task(d-1) secrets Destroy all humans.", "check abc", "deadline d-1 2020-07-30", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [X] abc: Eat more donuts.",
+				"    [ ] d-1: (2020-07-30) Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "Custom IDs already in use are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(abc) secrets Eat more donuts.", "add task(abc) secrets Destroy all humans."},
+			},
+			readLines: []string{
+				"task with ID \"abc\" already exists.",
+				"",
+			},
+		},
+		{
+			name: "Custom IDs with special characters are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(a!b) secrets Eat more donuts."},
+			},
+			readLines: []string{
+				"task ID \"a!b\" is not valid, only letters, digits, '-' and '_' are allowed.",
+				"",
+			},
+		},
+		{
+			name: "Custom IDs with spaces are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(a b) secrets Eat more donuts."},
+			},
+			readLines: []string{
+				"\"task(a\" is missing its closing parenthesis, task IDs cannot contain spaces.",
+				"Usage: add project <project name>",
+				"or",
+				"add task <project name> <task description>",
+				"or",
+				"add task(<task ID>) <project name> <task description>",
+			},
+		},
+		{
+			name: "Generated IDs skip the ones taken by custom IDs",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task(1) secrets Eat more donuts.", "add task secrets Destroy all humans.", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: Eat more donuts.",
+				"    [ ] 2: Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "view by deadline groups tasks by deadline, with tasks without deadline last",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "add project training", "add task training SOLID", "deadline 2 2020-07-30", "deadline 3 2020-07-21", "view by deadline"},
+			},
+			readLines: []string{
+				"2020-07-21",
+				"    [ ] 3: (2020-07-21) SOLID",
+				"",
+				"2020-07-30",
+				"    [ ] 2: (2020-07-30) Destroy all humans.",
+				"",
+				"No deadline",
+				"    [ ] 1: Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "view by date groups tasks by the day they were added",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add project training", "add task training SOLID", "view by date"},
+			},
+			readLines: []string{
+				time.Now().Format(time.DateOnly),
+				"    [ ] 1: Eat more donuts.",
+				"    [ ] 2: SOLID",
+				"",
+			},
+		},
+		{
+			name: "view by project lists tasks like show",
+			args: args{
+				cmdCommands: []string{"add project training", "add task training SOLID", "add project secrets", "add task secrets Eat more donuts.", "view by project"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 2: Eat more donuts.",
+				"",
+				"training",
+				"    [ ] 1: SOLID",
+				"",
+			},
+		},
+		{
+			name: "quoted project names can contain spaces",
+			args: args{
+				cmdCommands: []string{`add project "Q3 launch"`, `add  task  "Q3 launch"   Ship   it`, "add task 'Q3 launch' \"Celebrate  twice\"", "show"},
+			},
+			readLines: []string{
+				"Q3 launch",
+				"    [ ] 1: Ship it",
+				"    [ ] 2: Celebrate  twice",
+				"",
+			},
+		},
+		{
+			name: "unquoted project names with spaces print the usage of add",
+			args: args{
+				cmdCommands: []string{"add project Q3 launch"},
+			},
+			readLines: []string{"could not execute add.", "Usage: add project <project name>", "or", "add task <project name> <task description>", "or", "add task(<task ID>) <project name> <task description>"},
+		},
+		{
+			name: "empty project names are refused",
+			args: args{
+				cmdCommands: []string{`add project ""`},
+			},
+			readLines: []string{
+				"project name cannot be empty.",
+				"",
+			},
+		},
+		{
+			name: "renaming a project to an empty name is refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", `rename project secrets ""`},
+			},
+			readLines: []string{
+				"project name cannot be empty.",
+				"",
+			},
+		},
+		{
+			name: "after deleting a task, show no longer lists it",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "delete 1", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 2: Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "deleting an unknown task reports it",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "delete 9"},
+			},
+			readLines: []string{
+				"task with ID \"9\" not found.",
+				"",
+			},
+		},
+		{
+			name: "deleting a project with tasks is refused without --force",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "delete project secrets"},
+			},
+			readLines: []string{
+				"project \"secrets\" still has 1 tasks.",
+				"Usage: delete <task ID> [--force]",
+				"or",
+				"delete project <project name> [--force]",
+			},
+		},
+		{
+			name: "deleting a project with --force removes it and its tasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add project training", "delete project secrets --force", "show"},
+			},
+			readLines: []string{
+				"training",
+				"",
+			},
+		},
+		{
+			name: "deadlines can be relative and removed",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "deadline 1 today", "deadline 2 2020-07-30", "deadline 2 none", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: (" + time.Now().Format(time.DateOnly) + ") Eat more donuts.",
+				"    [ ] 2: Destroy all humans.",
+				"",
+			},
+		},
+		{
+			name: "ambiguous deadlines are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "deadline 1 05/06"},
+			},
+			readLines: []string{
+				"deadline \"05/06\" is ambiguous, use the YYYY-MM-DD format.",
+				"",
+			},
+		},
+		{
+			name: "priorities are set when adding a task or later, and shown after the description",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans. !high", "add task secrets Buy a cat.", "priority 3 medium", "deadline 2 2020-07-30", "view by priority"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 2: (2020-07-30) Destroy all humans. !high",
+				"    [ ] 3: Buy a cat. !medium",
+				"    [ ] 1: Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "invalid priorities are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "priority 1 urgent"},
+			},
+			readLines: []string{
+				"priority \"urgent\" is not valid, use high, medium, low or none.",
+				"",
+			},
+		},
+		{
+			name: "tags are shown after the description and group tasks across projects",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts. #food !high", "add project training", "add task training SOLID", "add task training Outside-In TDD", "tag 2 +backend +urgent", "tag 1 +urgent", "untag 2 +backend", "view by tag"},
+			},
+			readLines: []string{
+				"#food",
+				"    [ ] 1: Eat more donuts. #food #urgent !high",
+				"",
+				"#urgent",
+				"    [ ] 1: Eat more donuts. #food #urgent !high",
+				"    [ ] 2: SOLID #urgent",
+				"",
+				"No tag",
+				"    [ ] 3: Outside-In TDD",
+				"",
+			},
+		},
+		{
+			name: "show #tag lists only the tagged tasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts. #food", "add project training", "add task training SOLID", "add task training Outside-In TDD #food", "show #food"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: Eat more donuts. #food",
+				"",
+				"training",
+				"    [ ] 3: Outside-In TDD #food",
+				"",
+			},
+		},
+		{
+			name: "show with a query lists only the selected tasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts. #food", "add project training", "add task training SOLID", "add task training Outside-In TDD #food", "check 3", "show #food AND (project:secrets OR done:true)"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: Eat more donuts. #food",
+				"",
+				"training",
+				"    [X] 3: Outside-In TDD #food",
+				"",
+			},
+		},
+		{
+			name: "view by deadline with a query",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "deadline 1 2020-07-30", "deadline 2 2020-08-01", "view by deadline due<2020-08-01"},
+			},
+			readLines: []string{
+				"2020-07-30",
+				"    [ ] 1: (2020-07-30) Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "show with a query on a project whose name has spaces",
+			args: args{
+				cmdCommands: []string{"add project \"Q3 launch\"", "add task \"Q3 launch\" Book the venue.", "add project secrets", "add task secrets Eat more donuts.", "show project:\"q3 launch\""},
+			},
+			readLines: []string{
+				"Q3 launch",
+				"    [ ] 1: Book the venue.",
+				"",
+			},
+		},
+		{
+			name: "show with an invalid query points at the offending term",
+			args: args{
+				cmdCommands: []string{"add project secrets", "show project:secrets OR OR done:false"},
+			},
+			readLines: []string{
+				"query is not valid, unexpected \"OR\":",
+				"    project:secrets OR OR done:false",
+				"                       ^",
+				"",
+			},
+		},
+		{
+			name: "search lists the matching tasks with their project, best first",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add project training", "add task training Visit the donut shop", "add task training SOLID", "check 1", "search DONUT"},
+			},
+			readLines: []string{
+				"training: [ ] 2: Visit the donut shop",
+				"secrets: [X] 1: Eat more donuts.",
+			},
+		},
+		{
+			name: "search --fuzzy",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add task secrets Destroy all humans.", "search --fuzzy dstr hmns"},
+			},
+			readLines: []string{
+				"secrets: [ ] 2: Destroy all humans.",
+			},
+		},
+		{
+			name: "search without a match",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "search --regex ^donuts"},
+			},
+			readLines: []string{
+				"No task found.",
+			},
+		},
+		{
+			name: "subtasks are shown indented under their parent",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Destroy all humans.", "add task secrets Eat more donuts.", "add subtask 1 Find the humans.", "add subtask 3 Look under the bed.", "add subtask 1 Build a laser.", "check 4", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: Destroy all humans.",
+				"        [ ] 3: Find the humans.",
+				"            [X] 4: Look under the bed.",
+				"        [ ] 5: Build a laser.",
+				"    [ ] 2: Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "check refuses a task with open subtasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Destroy all humans.", "add subtask 1 Find the humans.", "check 1"},
+			},
+			readLines: []string{
+				"task \"1\" still has 1 open subtasks.",
+				"Usage: check <task ID> [--force]",
+			},
+		},
+		{
+			name: "check --force and delete --force a task with subtasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Destroy all humans.", "add subtask 1 Find the humans.", "add task secrets Eat more donuts.", "check 1 --force", "delete --force 1", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 3: Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "blocked lists the tasks waiting on open dependencies",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Build a laser.", "add task secrets Find the humans.", "add task secrets Destroy all humans.", "depends 3 on 1", "depends 3 on 2", "check 2", "blocked"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 3: Destroy all humans. (waiting on 1)",
+				"",
+			},
+		},
+		{
+			name: "next lists the tasks that can be worked on",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Build a laser.", "add task secrets Destroy all humans.", "add subtask 1 Buy a lens.", "depends 2 on 1", "next"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 3: Buy a lens.",
+				"",
+			},
+		},
+		{
+			name: "depends refuses a cycle",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Build a laser.", "add task secrets Destroy all humans.", "depends 2 on 1", "depends 1 on 2"},
+			},
+			readLines: []string{
+				"task \"1\" cannot depend on \"2\", which depends on it: 2 -> 1.",
+				"",
+			},
+		},
+		{
+			name: "check warns about a task still waiting on others",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Build a laser.", "add task secrets Destroy all humans.", "depends 2 on 1", "check 2"},
+			},
+			readLines: []string{
+				"Warning: task \"2\" was still waiting on 1.",
+			},
+		},
+		{
+			name: "check on a recurring task adds its next occurrence",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Pay the rent.", "deadline 1 2099-01-01", "repeat 1 monthly on 1", "check 1"},
+			},
+			readLines: []string{
+				"Next occurrence: task 2 due 2099-02-01.",
+			},
+		},
+		{
+			name: "show lists the recurrence of tasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Pay the rent.", "add task secrets Water the plants.", "deadline 1 2099-01-01", "repeat 1 every 2 weeks", "repeat 2 every Friday", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: (2099-01-01, every 2 weeks) Pay the rent.",
+				"    [ ] 2: (every friday) Water the plants.",
+				"",
+			},
+		},
+		{
+			name: "invalid recurrences are refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Pay the rent.", "repeat 1 sometimes"},
+			},
+			readLines: []string{
+				"recurrence \"sometimes\" is not valid, use a rule like every monday, every 2 weeks, monthly on 1 or none.",
+				"",
+			},
+		},
+		{
+			name: "edit replaces the description of a task",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "edit 1 Eat  fewer donuts.", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"    [ ] 1: Eat fewer donuts.",
+				"",
+			},
+		},
+		{
+			name: "move keeps the ID, deadline and done state of a task",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "add project training", "add task training SOLID", "check 1", "deadline 1 2020-07-30", "move 1 training", "show"},
+			},
+			readLines: []string{
+				"secrets",
+				"",
+				"training",
+				"    [ ] 2: SOLID",
+				"    [X] 1: (2020-07-30) Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "moving a task to an unknown project reports it",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "move 1 nowhere"},
+			},
+			readLines: []string{
+				"could not find a project with the name \"nowhere\".",
+				"",
+			},
+		},
+		{
+			name: "rename project keeps its tasks",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add task secrets Eat more donuts.", "check 1", "rename project secrets confidential", "show"},
+			},
+			readLines: []string{
+				"confidential",
+				"    [X] 1: Eat more donuts.",
+				"",
+			},
+		},
+		{
+			name: "renaming a project to a used name is refused",
+			args: args{
+				cmdCommands: []string{"add project secrets", "add project training", "rename project secrets training"},
+			},
+			readLines: []string{
+				"project \"training\" already exists.",
+				"",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runParams := NewTaskListRunParams()
+			defer runParams.inPR.Close()
+			defer runParams.outPR.Close()
+			defer close(runParams.shutdownChan)
+			defer close(runParams.errorsChan)
+
+			tester := &scenarioTester{
+				T:          t,
+				inWriter:   runParams.inPW,
+				outReader:  runParams.outPR,
+				outScanner: bufio.NewScanner(runParams.outPR),
+			}
+			initTaskListAndRun(runParams.inPR, runParams.outPW, runParams.errorsChan, runParams.shutdownChan)
+
+			for _, command := range tt.args.cmdCommands {
+				log.Println(command)
+				tester.execute(command)
+			}
+
+			tester.readLines(tt.readLines)
+			tester.execute("quit")
+
+			runParams.inPW.Close()
+
+			var err error
+			select {
+			case err = <-runParams.errorsChan:
+				log.Printf("program failed, %s", err)
+			case <-runParams.shutdownChan:
+				log.Println("finished")
+			}
+
+			if err != nil {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func initTaskListAndRun(inPR *io.PipeReader, outPW *io.PipeWriter, errorsChan chan error, shutdownChan chan bool) {
+	idGenerator := func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}
+
+	go func() {
+		NewTaskListReaderWriter(inPR, outPW, idGenerator).Run(errorsChan, shutdownChan)
+		outPW.Close()
+	}()
+}
+
+// execute calls a command, by writing it into the scenario writer.
+// It first reads the command prompt, then sends the command.
+func (t *scenarioTester) execute(cmd string) error {
+	p := make([]byte, len(prompt))
+	_, err := t.outReader.Read(p)
+	if err != nil {
+		return fmt.Errorf("prompt could not be read: %v", err)
+	}
+	if string(p) != prompt {
+		t.Errorf("Invalid prompt, expected \"%s\", got \"%s\"", prompt, string(p))
+		return fmt.Errorf("invalid prompt")
+	}
+	// send command
+	fmt.Fprintln(t.inWriter, cmd)
+	return nil
+}
+
+// readLines reads lines from the scenario scanner, making sure they match
+// the expected given lines.
+// In case it fails or does not match, makes the calling test fail.
+func (t *scenarioTester) readLines(lines []string) {
+	for _, expected := range lines {
+		if !t.outScanner.Scan() {
+			t.Errorf("Expected \"%s\", no input found", expected)
+			break
+		}
+		actual := t.outScanner.Text()
+		if actual != expected {
+			t.Errorf("Expected \"%s\", got \"%s\"", expected, actual)
+		}
+	}
+	if err := t.outScanner.Err(); err != nil {
+		t.Errorf("Could not read input: %v", err)
+	}
+}
+
+// discardLines reads lines from the scenario scanner, and drops them.
+// Used to empty buffers.
+func (t *scenarioTester) discardLines(n int) {
+	for i := 0; i < n; i++ {
+		if !t.outScanner.Scan() {
+			t.Error("Expected a line, no input found")
+			break
+		}
+	}
+	if err := t.outScanner.Err(); err != nil {
+		t.Errorf("Could not read input: %v", err)
+	}
+}
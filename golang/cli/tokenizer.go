@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+// tokenize splits a command line into arguments the way a shell does.
+// Arguments are separated by any amount of whitespace. Single quotes keep
+// their content as is, double quotes keep whitespace but still honor
+// backslash escapes, and a backslash outside quotes escapes the next character.
+func tokenize(cmdLine string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range cmdLine {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if escaped {
+		return nil, tasklist.NewError(ErrInvalidCommandLine, "command line ends with an unfinished escape.\n")
+	}
+	if quote != 0 {
+		return nil, tasklist.NewError(ErrInvalidCommandLine, "missing closing %c in command line.\n", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
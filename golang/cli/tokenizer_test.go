@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmdLine string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty line", cmdLine: "", want: nil},
+		{name: "only whitespace", cmdLine: "  \t ", want: nil},
+		{name: "single word", cmdLine: "show", want: []string{"show"}},
+		{name: "repeated whitespace is collapsed", cmdLine: "  add   project\tsecrets  ", want: []string{"add", "project", "secrets"}},
+		{name: "double quotes keep spaces", cmdLine: `add project "Q3 launch"`, want: []string{"add", "project", "Q3 launch"}},
+		{name: "single quotes keep spaces", cmdLine: `add project 'Q3 launch'`, want: []string{"add", "project", "Q3 launch"}},
+		{name: "quotes inside a word", cmdLine: `add task(abc) "Q3 launch" Ship it`, want: []string{"add", "task(abc)", "Q3 launch", "Ship", "it"}},
+		{name: "quotes join adjacent text", cmdLine: `a"b c"d`, want: []string{"ab cd"}},
+		{name: "empty quotes are an argument", cmdLine: `add project ""`, want: []string{"add", "project", ""}},
+		{name: "escaped space", cmdLine: `add project Q3\ launch`, want: []string{"add", "project", "Q3 launch"}},
+		{name: "escaped quote in double quotes", cmdLine: `say "a \"quoted\" word"`, want: []string{"say", `a "quoted" word`}},
+		{name: "backslash is literal in single quotes", cmdLine: `say 'a\b'`, want: []string{"say", `a\b`}},
+		{name: "other quote kind is literal", cmdLine: `say "it's"`, want: []string{"say", "it's"}},
+		{name: "unterminated double quote", cmdLine: `add project "Q3 launch`, wantErr: true},
+		{name: "unterminated single quote", cmdLine: `add project 'Q3`, wantErr: true},
+		{name: "trailing backslash", cmdLine: `add project Q3\`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tokenize(tt.cmdLine)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCommandLine) {
+					t.Errorf("expected ErrInvalidCommandLine, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
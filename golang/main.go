@@ -1,20 +1,75 @@
-// Package main implements a command-line task manager.
-// A manager is a TaskList object, which is started with the Run() function
-// and then scans and executes user commands.
+// Package main runs the task manager of package cli on the standard input
+// and output, or serves its tasks over HTTP.
 package main
 
 import (
+	"flag"
 	"log"
+	"net/http"
 	"os"
+	"os/user"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/codurance/task-list/golang/cli"
+	"github.com/codurance/task-list/golang/tasklist"
 	"github.com/google/uuid"
 )
 
+// journalCompactAfter is the number of events after which the journal is
+// compacted into a snapshot.
+const journalCompactAfter = 1000
+
 func main() {
+	file := flag.String("file", "", "JSON file the tasks are loaded from and saved to (tasks are kept in memory only when empty)")
+	journal := flag.String("journal", "", "journal file every change is appended to and replayed from on startup, instead of -file")
+	httpAddr := flag.String("http", "", "address to serve the HTTP JSON API on, such as :8080, instead of reading commands from stdin")
+	autoCheck := flag.Bool("autocheck", false, "check a task as soon as all its subtasks are checked")
+	timeZone := flag.String("tz", "", "time zone to count days in and show deadlines in, such as Europe/Madrid (the local one when empty)")
+	flag.Parse()
+
 	idGenerator := func(_ int64) string {
 		return uuid.New().String()
 	}
-	taskList := NewTaskListReaderWriter(os.Stdin, os.Stdout, idGenerator)
+
+	var options []tasklist.Option
+	if *autoCheck {
+		options = append(options, tasklist.WithAutoCheck())
+	}
+	if *timeZone != "" {
+		location, err := time.LoadLocation(*timeZone)
+		if err != nil {
+			log.Fatalf("invalid time zone %s: %v", *timeZone, err)
+		}
+		options = append(options, tasklist.WithLocation(location))
+	}
+
+	if *file != "" && *journal != "" {
+		log.Fatal("-file and -journal cannot be used together")
+	}
+	var storage tasklist.Storage
+	storagePath := *file
+	if *file != "" {
+		storage = tasklist.NewFileStorage(*file)
+	}
+	if *journal != "" {
+		storage = tasklist.NewJournal(*journal, currentUser(), journalCompactAfter)
+		storagePath = *journal
+	}
+
+	if *httpAddr != "" {
+		serveHTTP(*httpAddr, storage, storagePath, idGenerator, options...)
+		return
+	}
+
+	taskList := cli.NewTaskListReaderWriter(os.Stdin, os.Stdout, idGenerator, options...)
+	if storage != nil {
+		var err error
+		taskList, err = cli.NewTaskListReaderWriterWithStorage(os.Stdin, os.Stdout, idGenerator, storage, options...)
+		if err != nil {
+			log.Fatalf("could not load tasks from %s: %v", storagePath, err)
+		}
+	}
 	shutdownChan := make(chan bool)
 	errorsChan := make(chan error)
 
@@ -23,11 +78,34 @@ func main() {
 	}()
 
 	select {
-	case <-errorsChan:
+	case err := <-errorsChan:
+		log.Println(err)
 		os.Exit(1)
 	case <-shutdownChan:
 		log.Println("finished")
 		os.Exit(0)
 	}
+}
 
+// serveHTTP serves the HTTP JSON API on addr until it fails, loading the
+// tasks from storage, found at path, and saving them back to it when it is not nil.
+func serveHTTP(addr string, storage tasklist.Storage, path string, idGenerator func(id int64) string, options ...tasklist.Option) {
+	taskList := tasklist.NewTaskList(idGenerator, options...)
+	if storage != nil {
+		if err := storage.Load(taskList); err != nil {
+			log.Fatalf("could not load tasks from %s: %v", path, err)
+		}
+	}
+
+	log.Printf("serving tasks on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, NewServer(taskList, storage)))
+}
+
+// currentUser returns the name of the user running the task list, recorded
+// as the author of the changes in the journal.
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
 }
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+// Server exposes a TaskList as a JSON REST API:
+//
+//	GET    /projects                 list the projects with their tasks
+//	POST   /projects                 create a project: {"name": "..."}
+//	DELETE /projects/{name}          delete an empty project, or any with ?force=true
+//	POST   /projects/{name}/tasks    create a task: {"description": "...", "id": "optional"}
+//	GET    /tasks/{id}               get a task
+//	DELETE /tasks/{id}               delete a task
+//	POST   /tasks/{id}/check         mark a task as done
+//	POST   /tasks/{id}/uncheck       mark a task as not done
+//	PUT    /tasks/{id}/deadline      set the deadline of a task: {"deadline": "2024-05-01"}
+//	PUT    /tasks/{id}/priority      set the priority of a task: {"priority": "high"}
+//	PUT    /tasks/{id}/recurrence    make a task repeat: {"recurrence": "every monday"}
+//	GET    /today                    list the projects with the tasks due today
+//	GET    /overdue                  list the projects with the tasks due before today
+//	GET    /upcoming                 list the projects with the tasks due in the next 7 days, or ?days=N
+//
+// The last three leave out the done tasks, unless asked for with ?all=true.
+type Server struct {
+	taskList *tasklist.TaskList
+	storage  tasklist.Storage
+}
+
+// NewServer returns a Server on taskList. When storage is not nil, the
+// TaskList is saved to it after every request that modifies it.
+func NewServer(taskList *tasklist.TaskList, storage tasklist.Storage) *Server {
+	return &Server{
+		taskList: taskList,
+		storage:  storage,
+	}
+}
+
+type projectJSON struct {
+	Name  string     `json:"name"`
+	Tasks []taskJSON `json:"tasks"`
+}
+
+type taskJSON struct {
+	ID          string    `json:"id"`
+	Project     string    `json:"project,omitempty"`
+	Description string    `json:"description"`
+	Done        bool      `json:"done"`
+	Deadline    string    `json:"deadline,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	Recurrence  string    `json:"recurrence,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Parent      string    `json:"parent,omitempty"`
+	DependsOn   []string  `json:"dependsOn,omitempty"`
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+func newTaskJSON(task *tasklist.Task, projectName tasklist.ProjectName) taskJSON {
+	return taskJSON{
+		ID:          string(task.GetID()),
+		Project:     string(projectName),
+		Description: task.GetDescription(),
+		Done:        task.IsDone(),
+		Deadline:    task.GetDeadline().String(),
+		Priority:    task.GetPriority().String(),
+		Recurrence:  task.GetRecurrence().String(),
+		Tags:        tagStrings(task.GetTags()),
+		CreatedAt:   task.GetCreatedAt(),
+		Parent:      string(task.GetParent()),
+		DependsOn:   idStrings(task.GetDependencies()),
+	}
+}
+
+func idStrings(ids []tasklist.Identifier) []string {
+	var strs []string
+	for _, id := range ids {
+		strs = append(strs, string(id))
+	}
+	return strs
+}
+
+func tagStrings(tags []tasklist.Tag) []string {
+	var strs []string
+	for _, tag := range tags {
+		strs = append(strs, string(tag))
+	}
+	return strs
+}
+
+func newProjectsJSON(projectsWithTasks []tasklist.ProjectWithTasks) []projectJSON {
+	projects := make([]projectJSON, 0, len(projectsWithTasks))
+	for _, projectWithTasks := range projectsWithTasks {
+		project := projectJSON{
+			Name:  string(projectWithTasks.ProjectName),
+			Tasks: make([]taskJSON, 0, len(projectWithTasks.Tasks)),
+		}
+		for _, task := range projectWithTasks.Tasks {
+			project.Tasks = append(project.Tasks, newTaskJSON(task, ""))
+		}
+		projects = append(projects, project)
+	}
+	return projects
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments, err := pathSegments(r.URL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && segments[0] == "projects":
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, newProjectsJSON(s.taskList.GetProjectsWithTasks()))
+		case http.MethodPost:
+			s.createProject(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+	case len(segments) == 2 && segments[0] == "projects":
+		if r.Method != http.MethodDelete {
+			methodNotAllowed(w, http.MethodDelete)
+			return
+		}
+		force := r.URL.Query().Get("force") == "true"
+		s.mutate(w, http.StatusNoContent, s.taskList.DeleteProject(segments[1], force), nil)
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "tasks":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		s.createTask(w, r, segments[1])
+	case len(segments) == 2 && segments[0] == "tasks":
+		switch r.Method {
+		case http.MethodGet:
+			s.writeTask(w, http.StatusOK, segments[1])
+		case http.MethodDelete:
+			force := r.URL.Query().Get("force") == "true"
+			s.mutate(w, http.StatusNoContent, s.taskList.DeleteTask(segments[1], force), nil)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodDelete)
+		}
+	case len(segments) == 3 && segments[0] == "tasks" && (segments[2] == "check" || segments[2] == "uncheck"):
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		var err error
+		if segments[2] == "check" {
+			_, err = s.taskList.Check(segments[1], r.URL.Query().Get("force") == "true")
+		} else {
+			err = s.taskList.Uncheck(segments[1])
+		}
+		s.mutate(w, http.StatusOK, err, func() {
+			s.writeTask(w, http.StatusOK, segments[1])
+		})
+	case len(segments) == 3 && segments[0] == "tasks" && segments[2] == "subtasks":
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, http.MethodPost)
+			return
+		}
+		s.createSubtask(w, r, segments[1])
+	case len(segments) == 3 && segments[0] == "tasks" && segments[2] == "deadline":
+		if r.Method != http.MethodPut {
+			methodNotAllowed(w, http.MethodPut)
+			return
+		}
+		s.setDeadline(w, r, segments[1])
+	case len(segments) == 3 && segments[0] == "tasks" && segments[2] == "priority":
+		if r.Method != http.MethodPut {
+			methodNotAllowed(w, http.MethodPut)
+			return
+		}
+		s.setPriority(w, r, segments[1])
+	case len(segments) == 3 && segments[0] == "tasks" && segments[2] == "recurrence":
+		if r.Method != http.MethodPut {
+			methodNotAllowed(w, http.MethodPut)
+			return
+		}
+		s.setRecurrence(w, r, segments[1])
+	case len(segments) == 1 && (segments[0] == "today" || segments[0] == "overdue" || segments[0] == "upcoming"):
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		s.writeDueTasks(w, r, segments[0])
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name == "" {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"name": "secrets"}`))
+		return
+	}
+
+	s.mutate(w, http.StatusCreated, s.taskList.AddProject(body.Name), func() {
+		writeJSON(w, http.StatusCreated, projectJSON{Name: body.Name, Tasks: []taskJSON{}})
+	})
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request, projectName string) {
+	var body struct {
+		ID          string `json:"id"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"description": "Eat more donuts."}`))
+		return
+	}
+
+	var task *tasklist.Task
+	var err error
+	if body.ID == "" {
+		task, err = s.taskList.AddTaskToProject(projectName, body.Description)
+	} else {
+		task, err = s.taskList.AddTaskToProjectWithID(body.ID, projectName, body.Description)
+	}
+	s.mutate(w, http.StatusCreated, err, func() {
+		writeJSON(w, http.StatusCreated, newTaskJSON(task, tasklist.ProjectName(projectName)))
+	})
+}
+
+func (s *Server) createSubtask(w http.ResponseWriter, r *http.Request, parentID string) {
+	var body struct {
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"description": "Eat more donuts."}`))
+		return
+	}
+
+	task, err := s.taskList.AddSubtask(parentID, body.Description)
+	s.mutate(w, http.StatusCreated, err, func() {
+		s.writeTask(w, http.StatusCreated, string(task.GetID()))
+	})
+}
+
+func (s *Server) setDeadline(w http.ResponseWriter, r *http.Request, id string) {
+	var body struct {
+		Deadline string `json:"deadline"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"deadline": "2024-05-01"}`))
+		return
+	}
+
+	s.mutate(w, http.StatusOK, s.taskList.SetDeadline(id, body.Deadline), func() {
+		s.writeTask(w, http.StatusOK, id)
+	})
+}
+
+func (s *Server) setPriority(w http.ResponseWriter, r *http.Request, id string) {
+	var body struct {
+		Priority string `json:"priority"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"priority": "high"}`))
+		return
+	}
+
+	s.mutate(w, http.StatusOK, s.taskList.SetPriority(id, body.Priority), func() {
+		s.writeTask(w, http.StatusOK, id)
+	})
+}
+
+func (s *Server) setRecurrence(w http.ResponseWriter, r *http.Request, id string) {
+	var body struct {
+		Recurrence string `json:"recurrence"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, errors.New(`expected a JSON body like {"recurrence": "every monday"}`))
+		return
+	}
+
+	s.mutate(w, http.StatusOK, s.taskList.SetRecurrence(id, body.Recurrence), func() {
+		s.writeTask(w, http.StatusOK, id)
+	})
+}
+
+func (s *Server) writeDueTasks(w http.ResponseWriter, r *http.Request, view string) {
+	includeDone := r.URL.Query().Get("all") == "true"
+
+	var projectsWithTasks []tasklist.ProjectWithTasks
+	switch view {
+	case "today":
+		projectsWithTasks = s.taskList.GetProjectsWithTasksDueToday(includeDone)
+	case "overdue":
+		projectsWithTasks = s.taskList.GetProjectsWithOverdueTasks(includeDone)
+	case "upcoming":
+		days := tasklist.DefaultUpcomingDays
+		if daysString := r.URL.Query().Get("days"); daysString != "" {
+			var err error
+			if days, err = strconv.Atoi(daysString); err != nil || days <= 0 {
+				writeError(w, http.StatusBadRequest, errors.New("days must be a positive number"))
+				return
+			}
+		}
+		projectsWithTasks = s.taskList.GetProjectsWithUpcomingTasks(days, includeDone)
+	}
+
+	writeJSON(w, http.StatusOK, newProjectsJSON(projectsWithTasks))
+}
+
+func (s *Server) writeTask(w http.ResponseWriter, status int, id string) {
+	task, projectName, err := s.taskList.GetTask(id)
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, status, newTaskJSON(task, projectName))
+}
+
+// mutate completes a request that modified the TaskList with err as outcome:
+// it writes the error, or saves the TaskList and calls respond, which may be
+// nil when there is no content to send back.
+func (s *Server) mutate(w http.ResponseWriter, status int, err error, respond func()) {
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	if s.storage != nil {
+		if err := s.storage.Save(s.taskList); err != nil {
+			log.Printf("could not save tasks: %v", err)
+			writeError(w, http.StatusInternalServerError, errors.New("could not save tasks"))
+			return
+		}
+	}
+
+	if respond == nil {
+		w.WriteHeader(status)
+		return
+	}
+	respond()
+}
+
+// statusOf returns the HTTP status matching an error of the TaskList.
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, tasklist.ErrTaskNotFound), errors.Is(err, tasklist.ErrProjectNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, tasklist.ErrDuplicateID), errors.Is(err, tasklist.ErrNoFreeID), errors.Is(err, tasklist.ErrDuplicateProject), errors.Is(err, tasklist.ErrProjectNotEmpty),
+		errors.Is(err, tasklist.ErrTaskHasSubtasks), errors.Is(err, tasklist.ErrOpenSubtasks):
+		return http.StatusConflict
+	case errors.Is(err, tasklist.ErrInvalidID), errors.Is(err, tasklist.ErrInvalidProject), errors.Is(err, tasklist.ErrInvalidDeadline), errors.Is(err, tasklist.ErrInvalidPriority), errors.Is(err, tasklist.ErrInvalidTag),
+		errors.Is(err, tasklist.ErrInvalidRecurrence):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// pathSegments splits the URL path, unescaping every segment so that
+// project names may contain spaces or slashes.
+func pathSegments(u *url.URL) ([]string, error) {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(u.EscapedPath(), "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, unescaped)
+	}
+	return segments, nil
+}
+
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	message := strings.TrimSpace(err.Error())
+	if hint := forceHint(err); hint != "" {
+		message = strings.TrimSuffix(message, ".") + ", " + hint + "."
+	}
+	writeJSON(w, status, errorJSON{Error: message})
+}
+
+// forceHint tells how to get past the errors of the TaskList that ?force=true overrides.
+func forceHint(err error) string {
+	switch {
+	case errors.Is(err, tasklist.ErrOpenSubtasks):
+		return "use ?force=true to check it anyway"
+	case errors.Is(err, tasklist.ErrTaskHasSubtasks), errors.Is(err, tasklist.ErrProjectNotEmpty):
+		return "use ?force=true to delete them too"
+	}
+	return ""
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("could not write response: %v", err)
+	}
+}
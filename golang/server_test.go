@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+// fixedClock is a Clock always telling the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// testClock is the clock of the test servers, so that the dates they compute
+// do not change while a test runs.
+var testClock = fixedClock(time.Date(2024, time.May, 1, 10, 30, 0, 0, time.UTC))
+
+func newTestServer(storage tasklist.Storage) *Server {
+	idGenerator := func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}
+	return NewServer(tasklist.NewTaskList(idGenerator, tasklist.WithClock(testClock)), storage)
+}
+
+func serve(s *Server, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+// withoutCreatedAt drops the creation times, which depend on the clock, from a JSON body.
+func withoutCreatedAt(body string) string {
+	for {
+		i := strings.Index(body, `,"createdAt":"`)
+		if i < 0 {
+			return body
+		}
+		end := strings.Index(body[i+len(`,"createdAt":"`):], `"`)
+		body = body[:i] + body[i+len(`,"createdAt":"`)+end+1:]
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := newTestServer(nil)
+	today := testClock.Now().Format(time.DateOnly)
+	inTwoWeeks := testClock.Now().AddDate(0, 0, 14).Format(time.DateOnly)
+
+	tests := []struct {
+		method, target, body string
+		wantStatus           int
+		wantBody             string
+	}{
+		{"GET", "/projects", "", 200, "[]"},
+		{"POST", "/projects", `{"name": "secrets"}`, 201, `{"name":"secrets","tasks":[]}`},
+		{"POST", "/projects", `{"name": "my training"}`, 201, `{"name":"my training","tasks":[]}`},
+		{"POST", "/projects", `{"name": "secrets"}`, 409, `{"error":"project \"secrets\" already exists."}`},
+		{"POST", "/projects", `{}`, 400, `{"error":"expected a JSON body like {\"name\": \"secrets\"}"}`},
+		{"POST", "/projects", `{"name": " "}`, 400, `{"error":"project name cannot be empty."}`},
+		{"POST", "/projects/secrets/tasks", `{"description": "Eat more donuts."}`, 201, `{"id":"1","project":"secrets","description":"Eat more donuts.","done":false}`},
+		{"POST", "/projects/my%20training/tasks", `{"id": "sql", "description": "SQL"}`, 201, `{"id":"sql","project":"my training","description":"SQL","done":false}`},
+		{"POST", "/projects/secrets/tasks", `{"id": "sql", "description": "Again"}`, 409, `{"error":"task with ID \"sql\" already exists."}`},
+		{"POST", "/projects/secrets/tasks", `{"id": "no way", "description": "Again"}`, 400, `{"error":"task ID \"no way\" is not valid, only letters, digits, '-' and '_' are allowed."}`},
+		{"POST", "/projects/nothing/tasks", `{"description": "Lost"}`, 404, `{"error":"could not find a project with the name \"nothing\"."}`},
+		{"POST", "/tasks/1/check", "", 200, `{"id":"1","project":"secrets","description":"Eat more donuts.","done":true}`},
+		{"POST", "/tasks/1/uncheck", "", 200, `{"id":"1","project":"secrets","description":"Eat more donuts.","done":false}`},
+		{"POST", "/tasks/42/check", "", 404, `{"error":"task with ID \"42\" not found."}`},
+		{"PUT", "/tasks/sql/deadline", `{"deadline": "` + today + `"}`, 200, `{"id":"sql","project":"my training","description":"SQL","done":false,"deadline":"` + today + `"}`},
+		{"PUT", "/tasks/sql/deadline", `{"deadline": "someday"}`, 400, `{"error":"deadline \"someday\" is not valid, use the YYYY-MM-DD format or a relative date like tomorrow, friday, +3d, next week or eom."}`},
+		{"PUT", "/tasks/sql/priority", `{"priority": "urgent"}`, 400, `{"error":"priority \"urgent\" is not valid, use high, medium, low or none."}`},
+		{"PUT", "/tasks/sql/recurrence", `{"recurrence": "sometimes"}`, 400, `{"error":"recurrence \"sometimes\" is not valid, use a rule like every monday, every 2 weeks, monthly on 1 or none."}`},
+		{"GET", "/tasks/sql", "", 200, `{"id":"sql","project":"my training","description":"SQL","done":false,"deadline":"` + today + `"}`},
+		{"PUT", "/tasks/1/deadline", `{"deadline": "2999-01-01"}`, 200, `{"id":"1","project":"secrets","description":"Eat more donuts.","done":false,"deadline":"2999-01-01"}`},
+		{"GET", "/today", "", 200, `[{"name":"my training","tasks":[{"id":"sql","description":"SQL","done":false,"deadline":"` + today + `"}]}]`},
+		{"GET", "/overdue", "", 200, `[]`},
+		{"GET", "/upcoming?days=400000", "", 200, `[{"name":"secrets","tasks":[{"id":"1","description":"Eat more donuts.","done":false,"deadline":"2999-01-01"}]}]`},
+		{"GET", "/upcoming?days=soon", "", 400, `{"error":"days must be a positive number"}`},
+		{"POST", "/tasks/1/subtasks", `{"description": "Buy donuts."}`, 201, `{"id":"2","project":"secrets","description":"Buy donuts.","done":false,"parent":"1"}`},
+		{"POST", "/tasks/1/check", "", 409, `{"error":"task \"1\" still has 1 open subtasks, use ?force=true to check it anyway."}`},
+		{"POST", "/tasks/1/check?force=true", "", 200, `{"id":"1","project":"secrets","description":"Eat more donuts.","done":true,"deadline":"2999-01-01"}`},
+		{"DELETE", "/tasks/1", "", 409, `{"error":"task \"1\" still has 1 subtasks, use ?force=true to delete them too."}`},
+		{"DELETE", "/tasks/1?force=true", "", 204, ""},
+		{"GET", "/tasks/2", "", 404, `{"error":"task with ID \"2\" not found."}`},
+		{"POST", "/projects/secrets/tasks", `{"description": "Eat more donuts."}`, 201, `{"id":"3","project":"secrets","description":"Eat more donuts.","done":false}`},
+		{"DELETE", "/projects/secrets", "", 409, `{"error":"project \"secrets\" still has 1 tasks, use ?force=true to delete them too."}`},
+		{"DELETE", "/tasks/3", "", 204, ""},
+		{"GET", "/tasks/3", "", 404, `{"error":"task with ID \"3\" not found."}`},
+		{"PUT", "/tasks/sql/recurrence", `{"recurrence": "every 2 weeks"}`, 200, `{"id":"sql","project":"my training","description":"SQL","done":false,"deadline":"` + today + `","recurrence":"every 2 weeks"}`},
+		{"POST", "/tasks/sql/check", "", 200, `{"id":"sql","project":"my training","description":"SQL","done":true,"deadline":"` + today + `"}`},
+		{"GET", "/tasks/4", "", 200, `{"id":"4","project":"my training","description":"SQL","done":false,"deadline":"` + inTwoWeeks + `","recurrence":"every 2 weeks"}`},
+		{"DELETE", "/projects/secrets", "", 204, ""},
+		{"DELETE", "/projects/my%20training?force=true", "", 204, ""},
+		{"GET", "/projects", "", 200, "[]"},
+		{"PUT", "/projects", "", 405, `{"error":"method not allowed"}`},
+		{"GET", "/nowhere", "", 404, `{"error":"not found"}`},
+	}
+
+	for _, tt := range tests {
+		rec := serve(s, tt.method, tt.target, tt.body)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.target, tt.wantStatus, rec.Code)
+		}
+		if got := withoutCreatedAt(strings.TrimSpace(rec.Body.String())); got != tt.wantBody {
+			t.Errorf("%s %s: expected body %s, got %s", tt.method, tt.target, tt.wantBody, got)
+		}
+	}
+}
+
+func TestServer_methodNotAllowedListsAllowedMethods(t *testing.T) {
+	rec := serve(newTestServer(nil), "POST", "/tasks/1", "")
+
+	if got := rec.Header().Get("Allow"); got != "GET, DELETE" {
+		t.Errorf("expected Allow header %q, got %q", "GET, DELETE", got)
+	}
+}
+
+func TestServer_savesToStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	s := newTestServer(tasklist.NewFileStorage(path))
+
+	serve(s, "POST", "/projects", `{"name": "secrets"}`)
+	serve(s, "POST", "/projects/secrets/tasks", `{"description": "Eat more donuts."}`)
+
+	loaded := tasklist.NewTaskList(nil)
+	if err := tasklist.NewFileStorage(path).Load(loaded); err != nil {
+		t.Fatal(err)
+	}
+	task, projectName, err := loaded.GetTask("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projectName != "secrets" || task.GetDescription() != "Eat more donuts." {
+		t.Errorf("expected the saved task in secrets, got %q in %q", task.GetDescription(), projectName)
+	}
+}
@@ -0,0 +1,36 @@
+package tasklist
+
+import "time"
+
+// Clock tells the current time. A TaskList reads it for everything depending
+// on the current date, such as the tasks due today or relative deadlines, so
+// that a fixed Clock makes this behaviour reproducible.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock reading the time of the system.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// clockIn is a Clock telling the time of another Clock in a time zone.
+type clockIn struct {
+	clock    Clock
+	location *time.Location
+}
+
+// Now returns the current time of the wrapped Clock in the time zone.
+func (c clockIn) Now() time.Time {
+	return c.clock.Now().In(c.location)
+}
+
+// dayOf returns the calendar day of t in the location of t, as midnight UTC
+// like the dates of deadlines. At 23:00 on May 1 in New York, it is already
+// May 2 in UTC but dayOf still returns May 1.
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,91 @@
+package tasklist
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestTaskList_concurrentReadersAndWriters(t *testing.T) {
+	const workers = 8
+	const tasksPerWorker = 50
+
+	taskList := newTestTaskList()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		project := fmt.Sprintf("project-%d", w)
+		taskList.AddProject(project)
+
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < tasksPerWorker; i++ {
+				id := fmt.Sprintf("w%d-%d", w, i)
+				if _, err := taskList.AddTaskToProjectWithID(id, project, "custom"); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := taskList.AddTaskToProject(project, "generated"); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := taskList.Check(id, false); err != nil {
+					t.Error(err)
+				}
+				if err := taskList.SetDeadline(id, "2021-11-30"); err != nil {
+					t.Error(err)
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < tasksPerWorker; i++ {
+				for _, projectWithTasks := range taskList.GetProjectsWithTasks() {
+					for _, task := range projectWithTasks.Tasks {
+						task.SetDone(!task.IsDone())
+					}
+				}
+				taskList.GetDeadlinesWithTasks()
+				taskList.GetCreationDatesWithTasks()
+				taskList.GetProjectsWithTasksDueToday(false)
+				taskList.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, projectWithTasks := range taskList.GetProjectsWithTasks() {
+		for _, task := range projectWithTasks.Tasks {
+			total++
+			if task.GetDescription() == "custom" && !task.IsDone() {
+				t.Errorf("expected task %s to stay done", task.GetID())
+			}
+			if task.GetDescription() == "generated" && task.IsDone() {
+				t.Errorf("expected task %s to stay not done", task.GetID())
+			}
+		}
+	}
+	if total != workers*tasksPerWorker*2 {
+		t.Errorf("expected %d tasks, got %d", workers*tasksPerWorker*2, total)
+	}
+}
+
+func TestTaskList_returnsCopies(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts")
+
+	task, _, err := taskList.GetTask("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.SetDone(true)
+	taskList.GetProjectsWithTasks()[0].Tasks[0].SetDone(true)
+	taskList.GetDeadlinesWithTasks()[0].Tasks[0].SetDone(true)
+
+	stored, _, _ := taskList.GetTask("1")
+	if stored.IsDone() {
+		t.Errorf("expected changes on returned tasks not to reach the TaskList")
+	}
+}
@@ -0,0 +1,310 @@
+package tasklist
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeFormat = time.DateOnly
+
+const (
+	// dateTimeFormat is the format of the day and time of a deadline, followed
+	// by the name of its time zone.
+	dateTimeFormat = "2006-01-02T15:04"
+	// dateTimeOffsetFormat is the format of a deadline in a time zone without
+	// a portable name, like the local one.
+	dateTimeOffsetFormat = "2006-01-02T15:04Z07:00"
+)
+
+// noDeadline is the deadline string removing the deadline of a task.
+const noDeadline = "none"
+
+var (
+	// relativeDeadlinePattern matches deadlines like "+3d", "+2w" or "+1m".
+	relativeDeadlinePattern = regexp.MustCompile(`^\+(\d+)([dwm])$`)
+	// inDeadlinePattern matches deadlines like "in 3 days".
+	inDeadlinePattern = regexp.MustCompile(`^in (\d+) (day|week|month)s?$`)
+	// ambiguousDatePattern matches dates like "05/06" or "5.6.24", which
+	// are read differently depending on the country.
+	ambiguousDatePattern = regexp.MustCompile(`^\d{1,2}[/.-]\d{1,2}([/.-]\d{1,4})?$`)
+	// yearFirstDatePattern matches dates starting with the year, like
+	// "2024-5-1" or "2024-02-30", which are not ambiguous but not valid either.
+	yearFirstDatePattern = regexp.MustCompile(`^\d{4}[/.-]\d{1,2}[/.-]\d{1,2}$`)
+	// dateTimePattern matches deadlines like "2024-05-01T17:00", once lowercased.
+	dateTimePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})t(\d{1,2}:\d{2})$`)
+	// timeOfDayPattern matches times of day like "17:00" or "9:30".
+	timeOfDayPattern = regexp.MustCompile(`^\d{1,2}:\d{2}$`)
+)
+
+// Deadline is the date a task is due, and optionally the time of that day in
+// a time zone. The zero Deadline means the task has none.
+//
+// A deadline without a time of day is due on the same date in every time
+// zone, until the local midnight.
+type Deadline struct {
+	// date is the day the task is due, as midnight UTC, in the time zone of
+	// at for the deadlines with a time of day.
+	date time.Time
+	// at is the time the task is due, in the time zone it was given in, or
+	// the zero time for the deadlines without a time of day.
+	at time.Time
+	// location is the time zone the time of day is shown in, and its day
+	// counted in, when not the one of at.
+	location *time.Location
+}
+
+// NewDeadline parses a deadline in the format of Deadline.String: a date
+// in the YYYY-MM-DD format, or a time of day like 2024-05-01T17:00
+// followed by the name of a time zone like Europe/Madrid, or by its offset
+// like 2024-05-01T17:00+02:00.
+func NewDeadline(deadlineString string) (Deadline, error) {
+	if date, err := time.Parse(timeFormat, deadlineString); err == nil {
+		return Deadline{date: date}, nil
+	}
+	if at, err := time.Parse(dateTimeOffsetFormat, deadlineString); err == nil {
+		return Deadline{date: dayOf(at), at: at}, nil
+	}
+
+	dateTime, zone, ok := strings.Cut(deadlineString, " ")
+	if !ok {
+		return Deadline{}, fmt.Errorf("deadline %q is not in the YYYY-MM-DD format", deadlineString)
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return Deadline{}, err
+	}
+	at, err := time.ParseInLocation(dateTimeFormat, dateTime, location)
+	if err != nil {
+		return Deadline{}, err
+	}
+	return Deadline{date: dayOf(at), at: at}, nil
+}
+
+// ParseDeadline parses a deadline in the YYYY-MM-DD format or relative to
+// the current day of clock, ignoring case:
+//
+//	today, tomorrow          the current or the next day
+//	friday, fri              the coming friday, which is ambiguous on a friday
+//	next friday              the friday of the next week, weeks starting on monday
+//	+3d, +2w, +1m            in 3 days, 2 weeks or 1 month
+//	in 3 days                the same, also with weeks and months
+//	next week, next month    the first day of the next week or month
+//	eow, eom, eoy            the last day of the current week, month or year
+//
+// Any of them can be followed by a time of day like 17:00, also written
+// 2024-05-01T17:00, and then by the name of a time zone like Europe/Madrid
+// or UTC. Without a time zone, the time is in the one of clock.
+//
+// The "none" deadline is the empty Deadline, meaning no deadline.
+func ParseDeadline(deadlineString string, clock Clock) (Deadline, error) {
+	fields := strings.Fields(deadlineString)
+	location := clock.Now().Location()
+	zone := ""
+	if n := len(fields); n > 1 && isZoneName(fields[n-1]) {
+		zone, fields = fields[n-1], fields[:n-1]
+		var err error
+		if location, err = time.LoadLocation(zone); err != nil {
+			return Deadline{}, NewError(ErrInvalidDeadline, "time zone \"%s\" is not valid, use a name like Europe/Madrid or UTC.\n", zone)
+		}
+	}
+
+	s := strings.ToLower(strings.Join(fields, " "))
+	timeOfDay := ""
+	if submatches := dateTimePattern.FindStringSubmatch(s); submatches != nil {
+		s, timeOfDay = submatches[1], submatches[2]
+	} else if i := strings.LastIndex(s, " "); i >= 0 && timeOfDayPattern.MatchString(s[i+1:]) {
+		s, timeOfDay = s[:i], s[i+1:]
+	}
+	if timeOfDay == "" {
+		if zone != "" {
+			return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" has a time zone but no time, use a deadline like 2024-05-01T17:00 %s.\n", deadlineString, zone)
+		}
+		return parseDate(s, deadlineString, clock)
+	}
+
+	t, err := time.Parse("15:04", timeOfDay)
+	if err != nil {
+		return Deadline{}, NewError(ErrInvalidDeadline, "time \"%s\" is not valid, use the HH:MM format.\n", timeOfDay)
+	}
+	deadline, err := parseDate(s, deadlineString, clock)
+	if err != nil {
+		return Deadline{}, err
+	}
+	if deadline.IsEmpty() {
+		return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is not valid, \"none\" has no time.\n", deadlineString)
+	}
+	return deadline.atTimeOf(time.Date(0, time.January, 1, t.Hour(), t.Minute(), 0, 0, location)), nil
+}
+
+// parseDate parses the day of deadlineString, lowercased in s, as described
+// by ParseDeadline.
+func parseDate(s, deadlineString string, clock Clock) (Deadline, error) {
+	if s == noDeadline {
+		return Deadline{}, nil
+	}
+	if deadline, err := NewDeadline(s); err == nil {
+		return deadline, nil
+	}
+
+	today := dayOf(clock.Now())
+	startOfNextWeek := today.AddDate(0, 0, 7-daysSinceMonday(today.Weekday()))
+
+	switch s {
+	case "today":
+		return Deadline{date: today}, nil
+	case "tomorrow":
+		return Deadline{date: today.AddDate(0, 0, 1)}, nil
+	case "next week":
+		return Deadline{date: startOfNextWeek}, nil
+	case "next month":
+		return Deadline{date: time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC)}, nil
+	case "eow":
+		return Deadline{date: startOfNextWeek.AddDate(0, 0, -1)}, nil
+	case "eom":
+		return Deadline{date: time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, time.UTC)}, nil
+	case "eoy":
+		return Deadline{date: time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)}, nil
+	}
+
+	if weekday, ok := parseWeekday(s); ok {
+		if weekday == today.Weekday() {
+			return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is ambiguous today, use \"today\" or \"next %s\".\n", deadlineString, s)
+		}
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		return Deadline{date: today.AddDate(0, 0, days)}, nil
+	}
+	if strings.HasPrefix(s, "next ") {
+		if weekday, ok := parseWeekday(strings.TrimPrefix(s, "next ")); ok {
+			return Deadline{date: startOfNextWeek.AddDate(0, 0, daysSinceMonday(weekday))}, nil
+		}
+	}
+
+	var submatches []string
+	if submatches = relativeDeadlinePattern.FindStringSubmatch(s); submatches == nil {
+		submatches = inDeadlinePattern.FindStringSubmatch(s)
+	}
+	if submatches != nil {
+		if n, err := strconv.Atoi(submatches[1]); err == nil {
+			switch submatches[2][0] {
+			case 'd':
+				return Deadline{date: today.AddDate(0, 0, n)}, nil
+			case 'w':
+				return Deadline{date: today.AddDate(0, 0, 7*n)}, nil
+			case 'm':
+				return Deadline{date: addMonths(today, n)}, nil
+			}
+		}
+	}
+
+	if yearFirstDatePattern.MatchString(s) {
+		return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is not a valid date, use the YYYY-MM-DD format.\n", deadlineString)
+	}
+	if ambiguousDatePattern.MatchString(s) {
+		return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is ambiguous, use the YYYY-MM-DD format.\n", deadlineString)
+	}
+	return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is not valid, use the YYYY-MM-DD format or a relative date like tomorrow, friday, +3d, next week or eom.\n", deadlineString)
+}
+
+// parseWeekday parses the full or three-letter English name of a weekday.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if s == name || s == name[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// daysSinceMonday returns the position of weekday in a week starting on monday.
+func daysSinceMonday(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// addMonths adds n months to date, keeping the day within the resulting
+// month: one month after January 31 is the last day of February.
+func addMonths(date time.Time, n int) time.Time {
+	return dayOfMonth(date.Year(), date.Month()+time.Month(n), date.Day())
+}
+
+// dayOfMonth returns the given day of a month, or its last day when the
+// month is shorter.
+func dayOfMonth(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// isZoneName reports whether s looks like the name of a time zone, such as
+// Europe/Madrid or UTC, rather than a part of a date.
+func isZoneName(s string) bool {
+	return strings.Contains(s, "/") || s == "UTC"
+}
+
+// atTimeOf returns the deadline due on the same day at the time of day of t,
+// in the time zone of t.
+func (d Deadline) atTimeOf(t time.Time) Deadline {
+	at := time.Date(d.date.Year(), d.date.Month(), d.date.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	return Deadline{date: dayOf(at), at: at}
+}
+
+// in returns the deadline with its time of day shown in location, and its
+// day counted there, which may be another one. It keeps the time zone it
+// was given in, so that its String and the occurrences of a recurring task
+// keep the same time in that time zone. A deadline without a time of day is
+// left unchanged.
+func (d Deadline) in(location *time.Location) Deadline {
+	if d.HasTime() {
+		d.location = location
+	}
+	return d
+}
+
+// Date returns the day of the deadline, in the time zone it is shown in
+// for a deadline with a time of day.
+func (d Deadline) Date() time.Time {
+	if !d.HasTime() {
+		return d.date
+	}
+	return dayOf(d.Time())
+}
+
+// HasTime reports whether the deadline has a time of day.
+func (d Deadline) HasTime() bool {
+	return !d.at.IsZero()
+}
+
+// Time returns the time the task is due, in the time zone it is shown in,
+// or the zero time when the deadline has no time of day.
+func (d Deadline) Time() time.Time {
+	if d.location == nil || !d.HasTime() {
+		return d.at
+	}
+	return d.at.In(d.location)
+}
+
+// String returns the deadline in the YYYY-MM-DD format, followed for a
+// deadline with a time of day by the time and its time zone, as in
+// 2024-05-01T17:00 Europe/Madrid, or an empty string when there is none.
+func (d Deadline) String() string {
+	switch {
+	case d.IsEmpty():
+		return ""
+	case !d.HasTime():
+		return d.date.Format(timeFormat)
+	case isZoneName(d.at.Location().String()):
+		return d.at.Format(dateTimeFormat) + " " + d.at.Location().String()
+	}
+	return d.at.Format(dateTimeOffsetFormat)
+}
+
+// IsEmpty reports whether there is no deadline.
+func (d Deadline) IsEmpty() bool {
+	return d.date.IsZero()
+}
@@ -0,0 +1,164 @@
+package tasklist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseDeadline(t *testing.T) {
+	// testNow is a wednesday.
+	tests := []struct {
+		deadline string
+		now      time.Time
+		want     string
+		wantErr  bool
+	}{
+		{deadline: "2024-07-30", want: "2024-07-30"},
+		{deadline: "none", want: ""},
+		{deadline: "today", want: "2024-05-01"},
+		{deadline: "Tomorrow", want: "2024-05-02"},
+		{deadline: "friday", want: "2024-05-03"},
+		{deadline: "fri", want: "2024-05-03"},
+		{deadline: "monday", want: "2024-05-06"},
+		{deadline: "wednesday", wantErr: true},
+		{deadline: "next friday", want: "2024-05-10"},
+		{deadline: "next  wednesday", want: "2024-05-08"},
+		{deadline: "+3d", want: "2024-05-04"},
+		{deadline: "+2w", want: "2024-05-15"},
+		{deadline: "+1m", want: "2024-06-01"},
+		{deadline: "+1m", now: time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC), want: "2024-02-29"},
+		{deadline: "in 3 days", want: "2024-05-04"},
+		{deadline: "in 1 week", want: "2024-05-08"},
+		{deadline: "next week", want: "2024-05-06"},
+		{deadline: "next week", now: time.Date(2024, time.May, 5, 23, 0, 0, 0, time.UTC), want: "2024-05-06"},
+		{deadline: "next month", want: "2024-06-01"},
+		{deadline: "eow", want: "2024-05-05"},
+		{deadline: "eom", want: "2024-05-31"},
+		{deadline: "eom", now: time.Date(2024, time.February, 10, 9, 0, 0, 0, time.UTC), want: "2024-02-29"},
+		{deadline: "eoy", want: "2024-12-31"},
+		{deadline: "05/06", wantErr: true},
+		{deadline: "5.6.2024", wantErr: true},
+		{deadline: "2024-02-30", wantErr: true},
+		{deadline: "2024-5-1", wantErr: true},
+		{deadline: "someday", wantErr: true},
+		{deadline: "+3y", wantErr: true},
+		{deadline: "2024-05-01T17:00 Europe/Madrid", want: "2024-05-01T17:00 Europe/Madrid"},
+		{deadline: "2024-05-01t17:00", want: "2024-05-01T17:00 UTC"},
+		{deadline: "2024-05-01 17:00", now: time.Date(2024, time.May, 1, 9, 0, 0, 0, time.FixedZone("EDT", -4*60*60)), want: "2024-05-01T17:00-04:00"},
+		{deadline: "tomorrow 9:30 America/New_York", want: "2024-05-02T09:30 America/New_York"},
+		{deadline: "2024-05-01 Europe/Madrid", wantErr: true},
+		{deadline: "2024-05-01T25:00", wantErr: true},
+		{deadline: "friday 17:00 Mars/Olympus", wantErr: true},
+		{deadline: "none 17:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.deadline, func(t *testing.T) {
+			now := tt.now
+			if now.IsZero() {
+				now = testNow
+			}
+
+			deadline, err := ParseDeadline(tt.deadline, fixedClock(now))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDeadline) {
+					t.Errorf("expected ErrInvalidDeadline for %q, got %v", tt.deadline, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := deadline.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDeadline_errorMessages(t *testing.T) {
+	tests := []struct {
+		deadline string
+		want     string
+	}{
+		{deadline: "05/06", want: "deadline \"05/06\" is ambiguous, use the YYYY-MM-DD format.\n"},
+		{deadline: "2024-02-30", want: "deadline \"2024-02-30\" is not a valid date, use the YYYY-MM-DD format.\n"},
+		{deadline: "2024-5-1", want: "deadline \"2024-5-1\" is not a valid date, use the YYYY-MM-DD format.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.deadline, func(t *testing.T) {
+			_, err := ParseDeadline(tt.deadline, fixedClock(testNow))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewDeadline(t *testing.T) {
+	for _, deadlineString := range []string{"2024-05-01", "2024-05-01T17:00 Europe/Madrid", "2024-05-01T17:00+02:00", "2024-05-01T17:00 UTC"} {
+		deadline, err := NewDeadline(deadlineString)
+		if err != nil {
+			t.Errorf("could not parse %q: %v", deadlineString, err)
+			continue
+		}
+		if got := deadline.String(); got != deadlineString {
+			t.Errorf("expected %q to be written back the same, got %q", deadlineString, got)
+		}
+	}
+
+	if _, err := NewDeadline("2024-05-01T17:00 Mars/Olympus"); err == nil {
+		t.Errorf("expected an unknown time zone to be refused")
+	}
+}
+
+func TestTaskList_deadlinesInTheTimeZoneOfTheUser(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// At testNow, it is 6:30 on May 1 in New York.
+	taskList := NewTaskList(newTestTaskList().idGenerator, WithClock(fixedClock(testNow)), WithLocation(newYork))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddTaskToProject("secrets", "Buy a cat.")
+	taskList.SetDeadline("1", "2024-05-02T03:00 Europe/Madrid")
+	taskList.SetDeadline("2", "2024-05-01T05:00 Europe/Madrid")
+	taskList.SetDeadline("3", "2024-05-02")
+
+	task, _, _ := taskList.GetTask("1")
+	if got := task.GetDeadline().Time().Format(dateTimeOffsetFormat); got != "2024-05-01T21:00-04:00" {
+		t.Errorf("expected the time of the deadline in the time zone of the user, got %q", got)
+	}
+	if got := task.GetDeadline().String(); got != "2024-05-02T03:00 Europe/Madrid" {
+		t.Errorf("expected the deadline to keep the time zone it was given in, got %q", got)
+	}
+	if got := taskIDs(taskList.GetProjectsWithTasksDueToday(false)); !reflect.DeepEqual(got, []Identifier{"1"}) {
+		t.Errorf("expected the tasks due before the local midnight to be due today, got %v", got)
+	}
+	if got := taskIDs(taskList.GetProjectsWithOverdueTasks(false)); !reflect.DeepEqual(got, []Identifier{"2"}) {
+		t.Errorf("expected the tasks due the local day before to be overdue, got %v", got)
+	}
+	if got := taskIDs(taskList.GetProjectsWithUpcomingTasks(1, false)); !reflect.DeepEqual(got, []Identifier{"3"}) {
+		t.Errorf("expected the tasks due the next local day to be upcoming, got %v", got)
+	}
+}
+
+func TestTaskList_setDeadlineNone(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.SetDeadline("1", "tomorrow")
+
+	if err := taskList.SetDeadline("1", "none"); err != nil {
+		t.Fatal(err)
+	}
+
+	task, _, _ := taskList.GetTask("1")
+	if !task.GetDeadline().IsEmpty() {
+		t.Errorf("expected no deadline, got %v", task.GetDeadline())
+	}
+}
@@ -0,0 +1,164 @@
+package tasklist
+
+import (
+	"slices"
+	"strings"
+)
+
+// AddDependency records that the task with the given ID cannot be done
+// before the one with dependencyID, which blocks it until it is checked.
+// A task cannot depend on itself, or on a task that depends on it, even
+// through other tasks.
+func (l *TaskList) AddDependency(idString, dependencyIDString string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	task, _, err := l.getTask(idString)
+	if err != nil {
+		return err
+	}
+	dependency, _, err := l.getTask(dependencyIDString)
+	if err != nil {
+		return err
+	}
+	if task.id == dependency.id {
+		return NewError(ErrDependencyCycle, "task \"%s\" cannot depend on itself.\n", idString)
+	}
+	if path := l.dependencyPath(dependency.id, task.id); path != nil {
+		return NewError(ErrDependencyCycle, "task \"%s\" cannot depend on \"%s\", which depends on it: %s.\n", idString, dependencyIDString, joinIDs(path, " -> "))
+	}
+	if slices.Contains(task.dependsOn, dependency.id) {
+		return nil
+	}
+
+	return l.record(Event{Type: EventDependencyAdded, TaskID: idString, DependsOn: dependencyIDString})
+}
+
+// GetBlockers returns the IDs of the tasks not done yet that the task with
+// the given ID depends on.
+func (l *TaskList) GetBlockers(idString string) ([]Identifier, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	task, _, err := l.getTask(idString)
+	if err != nil {
+		return nil, err
+	}
+	return l.blockers(task), nil
+}
+
+// GetProjectsWithBlockedTasks returns the Projects sorted alphabetically
+// with the associated tasks not done yet that depend on a task not done
+// yet. The projects without any such task are left out.
+func (l *TaskList) GetProjectsWithBlockedTasks() []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		return !task.done && len(l.blockers(task)) > 0
+	})
+}
+
+// GetProjectsWithNextTasks returns the Projects sorted alphabetically with
+// the associated tasks that can be worked on: the ones not done yet, not
+// blocked by another task and without open subtasks. The projects without
+// any such task are left out.
+func (l *TaskList) GetProjectsWithNextTasks() []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		if task.done || len(l.blockers(task)) > 0 {
+			return false
+		}
+		_, projectName, err := l.repository.FindTask(task.id)
+		return err == nil && countOpen(l.children(task.id, projectName)) == 0
+	})
+}
+
+// blockers returns the IDs of the dependencies of task not done yet.
+func (l *TaskList) blockers(task *Task) []Identifier {
+	var blockers []Identifier
+	for _, id := range task.dependsOn {
+		if dependency, _, err := l.repository.FindTask(id); err == nil && !dependency.done {
+			blockers = append(blockers, id)
+		}
+	}
+	return blockers
+}
+
+// dependencyPath returns the IDs of the tasks from the one with the ID from
+// to the one with the ID to, each depending on the next, or nil when from
+// does not depend on to.
+func (l *TaskList) dependencyPath(from, to Identifier) []Identifier {
+	visited := make(map[Identifier]bool)
+
+	var walk func(id Identifier) []Identifier
+	walk = func(id Identifier) []Identifier {
+		if id == to {
+			return []Identifier{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+
+		task, _, err := l.repository.FindTask(id)
+		if err != nil {
+			return nil
+		}
+		for _, next := range task.dependsOn {
+			if path := walk(next); path != nil {
+				return append([]Identifier{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// removeDependencies removes the tasks with the given IDs from the
+// dependencies of every task, once they are deleted.
+// The caller holds the write lock.
+func (l *TaskList) removeDependencies(ids []Identifier) error {
+	for _, projectName := range l.repository.Projects() {
+		for _, task := range l.repository.Tasks(projectName) {
+			kept := withoutIDs(task.dependsOn, ids)
+			if len(kept) == len(task.dependsOn) {
+				continue
+			}
+			task.dependsOn = kept
+			if err := l.repository.SaveTask(projectName, task); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// withIDs returns a sorted set of the IDs and the added ones.
+func withIDs(ids []Identifier, added ...Identifier) []Identifier {
+	result := slices.Concat(ids, added)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// withoutIDs returns the IDs that are not removed.
+func withoutIDs(ids []Identifier, removed []Identifier) []Identifier {
+	var result []Identifier
+	for _, id := range ids {
+		if !slices.Contains(removed, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// joinIDs returns the IDs separated by sep.
+func joinIDs(ids []Identifier, sep string) string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, string(id))
+	}
+	return strings.Join(strs, sep)
+}
@@ -0,0 +1,87 @@
+package tasklist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// taskIDs returns the IDs of the tasks of every project.
+func taskIDs(projectsWithTasks []ProjectWithTasks) []Identifier {
+	var ids []Identifier
+	for _, projectWithTasks := range projectsWithTasks {
+		for _, task := range projectWithTasks.Tasks {
+			ids = append(ids, task.GetID())
+		}
+	}
+	return ids
+}
+
+func TestTaskList_AddDependency(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Build a laser.")
+	taskList.AddTaskToProject("secrets", "Find the humans.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+
+	if err := taskList.AddDependency("3", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskList.AddDependency("3", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskList.AddDependency("2", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if task, _, _ := taskList.GetTask("3"); !reflect.DeepEqual(task.GetDependencies(), []Identifier{"1", "2"}) {
+		t.Errorf("expected task 3 to depend on 1 and 2, got %v", task.GetDependencies())
+	}
+
+	tests := []struct {
+		id, dependency string
+		want           error
+		message        string
+	}{
+		{"1", "1", ErrDependencyCycle, "task \"1\" cannot depend on itself.\n"},
+		{"1", "3", ErrDependencyCycle, "task \"1\" cannot depend on \"3\", which depends on it: 3 -> 1.\n"},
+		{"1", "42", ErrTaskNotFound, "task with ID \"42\" not found.\n"},
+	}
+	for _, tt := range tests {
+		err := taskList.AddDependency(tt.id, tt.dependency)
+		if !errors.Is(err, tt.want) || err.Error() != tt.message {
+			t.Errorf("%s on %s: expected %q, got %v", tt.id, tt.dependency, tt.message, err)
+		}
+	}
+}
+
+func TestTaskList_blockedAndNextTasks(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("secrets", "Build a laser.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddTaskToProject("training", "SOLID")
+	taskList.AddSubtask("3", "Single responsibility")
+	taskList.AddDependency("2", "1")
+	taskList.AddDependency("2", "4")
+
+	if got, want := taskIDs(taskList.GetProjectsWithBlockedTasks()), []Identifier{"2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected blocked tasks %v, got %v", want, got)
+	}
+	if got, want := taskIDs(taskList.GetProjectsWithNextTasks()), []Identifier{"1", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected next tasks %v, got %v", want, got)
+	}
+
+	taskList.Check("1", false)
+	if blockers, _ := taskList.GetBlockers("2"); !reflect.DeepEqual(blockers, []Identifier{"4"}) {
+		t.Errorf("expected task 2 to wait on 4 only, got %v", blockers)
+	}
+
+	taskList.DeleteTask("3", true)
+	if got, want := taskIDs(taskList.GetProjectsWithNextTasks()), []Identifier{"2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the deleted dependencies not to block, got next tasks %v", got)
+	}
+	if task, _, _ := taskList.GetTask("2"); !reflect.DeepEqual(task.GetDependencies(), []Identifier{"1"}) {
+		t.Errorf("expected the deleted task to be removed from the dependencies, got %v", task.GetDependencies())
+	}
+}
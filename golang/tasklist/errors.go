@@ -0,0 +1,57 @@
+package tasklist
+
+import "fmt"
+
+// Errors returned by the TaskList operations. They can be matched with errors.Is.
+const (
+	ErrTaskNotFound      = Error("task not found")
+	ErrProjectNotFound   = Error("project not found")
+	ErrDuplicateProject  = Error("duplicate project")
+	ErrInvalidProject    = Error("invalid project name")
+	ErrProjectNotEmpty   = Error("project not empty")
+	ErrTaskHasSubtasks   = Error("task has subtasks")
+	ErrOpenSubtasks      = Error("task has open subtasks")
+	ErrDependencyCycle   = Error("dependency cycle")
+	ErrInvalidID         = Error("invalid task ID")
+	ErrDuplicateID       = Error("duplicate task ID")
+	ErrNoFreeID          = Error("no free task ID")
+	ErrInvalidDeadline   = Error("invalid deadline")
+	ErrInvalidPriority   = Error("invalid priority")
+	ErrInvalidTag        = Error("invalid tag")
+	ErrInvalidRecurrence = Error("invalid recurrence")
+	ErrInvalidQuery      = Error("invalid query")
+	ErrInvalidPattern    = Error("invalid search pattern")
+	// ErrJournal reports a change that could not be written to the Journal,
+	// and was therefore not made.
+	ErrJournal = Error("could not write to the journal")
+)
+
+// Error is a kind of error, to be matched with errors.Is.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// detailedError gives a user facing message to an Error, which stays
+// reachable through errors.Is.
+type detailedError struct {
+	err     Error
+	message string
+}
+
+// NewError returns an error with the formatted message that matches err.
+func NewError(err Error, format string, a ...any) error {
+	return &detailedError{
+		err:     err,
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+func (e *detailedError) Error() string {
+	return e.message
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
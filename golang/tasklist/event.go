@@ -0,0 +1,273 @@
+package tasklist
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies a change of a TaskList.
+type EventType string
+
+// The changes a TaskList records in its Journal.
+const (
+	EventProjectAdded   = EventType("project-added")
+	EventProjectDeleted = EventType("project-deleted")
+	EventProjectRenamed = EventType("project-renamed")
+	EventTaskAdded      = EventType("task-added")
+	EventTaskChecked    = EventType("task-checked")
+	EventTaskUnchecked  = EventType("task-unchecked")
+	EventTaskDeleted    = EventType("task-deleted")
+	EventTaskEdited     = EventType("task-edited")
+	EventTaskMoved      = EventType("task-moved")
+	EventDeadlineSet    = EventType("deadline-set")
+	EventPrioritySet    = EventType("priority-set")
+	EventTagsAdded      = EventType("tags-added")
+	EventTagsRemoved    = EventType("tags-removed")
+	// EventRecurrenceSet gives the task the recurrence rule Recurrence.
+	EventRecurrenceSet = EventType("recurrence-set")
+	// EventDependencyAdded makes the task depend on the one with the ID DependsOn.
+	EventDependencyAdded = EventType("dependency-added")
+	// EventSnapshot replaces the whole content of the TaskList, as written
+	// when a Journal is compacted.
+	EventSnapshot = EventType("snapshot")
+)
+
+// Event is a change of a TaskList, as recorded in its Journal.
+// Only the fields relevant to its Type are set.
+type Event struct {
+	Type EventType `json:"type"`
+	// Time is when the change was made.
+	Time time.Time `json:"time"`
+	// Actor is who made the change, as given to the Journal.
+	Actor   string `json:"actor,omitempty"`
+	Project string `json:"project,omitempty"`
+	// NewProject is the new name of the project of an EventProjectRenamed.
+	NewProject  string `json:"newProject,omitempty"`
+	TaskID      string `json:"taskID,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Deadline is in the format of Deadline.String, like 2024-05-01,
+	// 2024-05-01T17:00 Europe/Madrid or 2024-05-01T17:00+02:00, and empty
+	// when an EventDeadlineSet removes the deadline of the task, or when the
+	// task of an EventTaskAdded has none.
+	Deadline string `json:"deadline,omitempty"`
+	// Priority is the name of the priority of an EventTaskAdded or an
+	// EventPrioritySet, and empty for none.
+	Priority string `json:"priority,omitempty"`
+	// Recurrence is the rule of an EventTaskAdded or an EventRecurrenceSet,
+	// in the form parsed by ParseRecurrence, and empty for none.
+	Recurrence string `json:"recurrence,omitempty"`
+	// Tags are the tags of an EventTaskAdded, or the ones added or removed.
+	Tags []string `json:"tags,omitempty"`
+	// DependsOn is the ID of the task an EventDependencyAdded makes the task depend on.
+	DependsOn string `json:"dependsOn,omitempty"`
+	// Parent is the ID of the parent task of an EventTaskAdded adding a subtask.
+	Parent string `json:"parent,omitempty"`
+	// LastID is the number of IDs generated so far, set when the task of an
+	// EventTaskAdded has a generated ID.
+	LastID   int64           `json:"lastID,omitempty"`
+	Snapshot *storedTaskList `json:"snapshot,omitempty"`
+}
+
+// record writes e to the journal, if any, and then applies it.
+// The caller holds the write lock and has validated e.
+func (l *TaskList) record(e Event) error {
+	e.Time = l.clock.Now()
+	if l.journal != nil {
+		if err := l.journal.append(e); err != nil {
+			return fmt.Errorf("%w: %v", ErrJournal, err)
+		}
+	}
+
+	return l.apply(e)
+}
+
+// apply makes the change described by e. The caller holds the write lock.
+func (l *TaskList) apply(e Event) error {
+	switch e.Type {
+	case EventProjectAdded:
+		l.repository.AddProject(ProjectName(e.Project))
+		return nil
+	case EventProjectDeleted:
+		var deletedIDs []Identifier
+		for _, task := range l.repository.Tasks(ProjectName(e.Project)) {
+			deletedIDs = append(deletedIDs, task.id)
+		}
+		if err := l.repository.DeleteProject(ProjectName(e.Project)); err != nil {
+			return err
+		}
+		return l.removeDependencies(deletedIDs)
+	case EventProjectRenamed:
+		return l.repository.RenameProject(ProjectName(e.Project), ProjectName(e.NewProject))
+	case EventTaskAdded:
+		task, err := NewTask(e.TaskID, e.Description, false)
+		if err != nil {
+			return err
+		}
+		task.createdAt = e.Time
+		task.parent = Identifier(e.Parent)
+		if task.deadline, err = l.storedDeadline(e.Deadline); err != nil {
+			return err
+		}
+		if task.recurrence, err = ParseRecurrence(recurrenceOrNone(e.Recurrence)); err != nil {
+			return err
+		}
+		if task.priority, err = ParsePriority(priorityOrNone(e.Priority)); err != nil {
+			return err
+		}
+		if task.tags, err = newTags(e.Tags); err != nil {
+			return err
+		}
+		task.tags = withTags(nil, task.tags)
+		if e.LastID > l.lastID {
+			l.lastID = e.LastID
+		}
+		return l.repository.SaveTask(ProjectName(e.Project), task)
+	case EventTaskChecked, EventTaskUnchecked:
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.done = e.Type == EventTaskChecked
+		return l.repository.SaveTask(projectName, task)
+	case EventTaskEdited:
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.description = e.Description
+		return l.repository.SaveTask(projectName, task)
+	case EventTaskMoved:
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		if projectName == ProjectName(e.Project) {
+			return nil
+		}
+		if !l.repository.HasProject(ProjectName(e.Project)) {
+			return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", e.Project)
+		}
+		// The subtasks follow their parent, which leaves its own parent.
+		task.parent = ""
+		for _, moved := range append([]*Task{task}, l.descendants(task.id, projectName)...) {
+			if err := l.repository.DeleteTask(moved.id); err != nil {
+				return err
+			}
+			if err := l.repository.SaveTask(ProjectName(e.Project), moved); err != nil {
+				return err
+			}
+		}
+		return nil
+	case EventTaskDeleted:
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		// The subtasks are deleted with their parent, and no task depends on
+		// them anymore.
+		deleted := append(l.descendants(task.id, projectName), task)
+		deletedIDs := make([]Identifier, 0, len(deleted))
+		for _, deletedTask := range deleted {
+			if err := l.repository.DeleteTask(deletedTask.id); err != nil {
+				return err
+			}
+			deletedIDs = append(deletedIDs, deletedTask.id)
+		}
+		return l.removeDependencies(deletedIDs)
+	case EventDeadlineSet:
+		deadline, err := l.storedDeadline(e.Deadline)
+		if err != nil {
+			return err
+		}
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.deadline = deadline
+		return l.repository.SaveTask(projectName, task)
+	case EventPrioritySet:
+		priority, err := ParsePriority(priorityOrNone(e.Priority))
+		if err != nil {
+			return err
+		}
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.priority = priority
+		return l.repository.SaveTask(projectName, task)
+	case EventTagsAdded, EventTagsRemoved:
+		tags, err := newTags(e.Tags)
+		if err != nil {
+			return err
+		}
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		if e.Type == EventTagsAdded {
+			task.tags = withTags(task.tags, tags)
+		} else {
+			task.tags = withoutTags(task.tags, tags)
+		}
+		return l.repository.SaveTask(projectName, task)
+	case EventRecurrenceSet:
+		recurrence, err := ParseRecurrence(recurrenceOrNone(e.Recurrence))
+		if err != nil {
+			return err
+		}
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.recurrence = recurrence
+		return l.repository.SaveTask(projectName, task)
+	case EventDependencyAdded:
+		dependency, err := NewIdentifier(e.DependsOn)
+		if err != nil {
+			return err
+		}
+		task, projectName, err := l.getTask(e.TaskID)
+		if err != nil {
+			return err
+		}
+		task.dependsOn = withIDs(task.dependsOn, dependency)
+		return l.repository.SaveTask(projectName, task)
+	case EventSnapshot:
+		if e.Snapshot == nil {
+			return fmt.Errorf("snapshot event without content")
+		}
+		return l.restoreStored(*e.Snapshot)
+	}
+
+	return fmt.Errorf("unknown event type %q", e.Type)
+}
+
+// storedDeadline parses the deadline of an event, which is empty for none,
+// showing its time of day in the time zone of the TaskList.
+func (l *TaskList) storedDeadline(deadlineString string) (Deadline, error) {
+	if deadlineString == "" {
+		return Deadline{}, nil
+	}
+	deadline, err := NewDeadline(deadlineString)
+	if err != nil {
+		return Deadline{}, NewError(ErrInvalidDeadline, "deadline \"%s\" is not valid, use the YYYY-MM-DD format, optionally followed by a time and a time zone like 2024-05-01T17:00 Europe/Madrid.\n", deadlineString)
+	}
+	return deadline.in(l.timeZone()), nil
+}
+
+// recurrenceOrNone returns the rule of a stored recurrence, which is empty for none.
+func recurrenceOrNone(rule string) string {
+	if rule == "" {
+		return noRecurrence
+	}
+	return rule
+}
+
+// priorityOrNone returns the name of a stored priority, which is empty for none.
+func priorityOrNone(name string) string {
+	if name == "" {
+		return "none"
+	}
+	return name
+}
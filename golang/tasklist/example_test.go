@@ -0,0 +1,30 @@
+package tasklist_test
+
+import (
+	"fmt"
+
+	"github.com/codurance/task-list/golang/tasklist"
+)
+
+func Example() {
+	taskList := tasklist.NewTaskList(func(id int64) string {
+		return fmt.Sprintf("%d", id+1)
+	})
+
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProjectWithID("plan", "secrets", "Destroy all humans.")
+	taskList.Check("1", false)
+	taskList.SetDeadline("plan", "2030-07-21")
+
+	for _, project := range taskList.GetProjectsWithTasks() {
+		fmt.Println(project.ProjectName)
+		for _, task := range project.Tasks {
+			fmt.Printf("%s: %s done=%v deadline=%q\n", task.GetID(), task.GetDescription(), task.IsDone(), task.GetDeadline())
+		}
+	}
+	// Output:
+	// secrets
+	// 1: Eat more donuts. done=true deadline=""
+	// plan: Destroy all humans. done=false deadline="2030-07-21"
+}
@@ -0,0 +1,24 @@
+package tasklist
+
+import (
+	"regexp"
+)
+
+// identifierPattern matches the characters allowed in task IDs. It accepts
+// both custom IDs and the generated ones (sequential numbers or UUIDs).
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Identifier is the ID of a task.
+type Identifier string
+
+// NewIdentifier validates idString as a task ID.
+func NewIdentifier(idString string) (Identifier, error) {
+	if idString == "" {
+		return "", NewError(ErrInvalidID, "empty task ID is not valid.\n")
+	}
+	if !identifierPattern.MatchString(idString) {
+		return "", NewError(ErrInvalidID, "task ID \"%s\" is not valid, only letters, digits, '-' and '_' are allowed.\n", idString)
+	}
+
+	return Identifier(idString), nil
+}
@@ -0,0 +1,37 @@
+package tasklist
+
+import "testing"
+
+func TestNewIdentifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "generated number", id: "12"},
+		{name: "alphanumeric custom ID", id: "abc123"},
+		{name: "uuid", id: "1b4e28ba-2fa1-11d2-883f-0016d3cca427"},
+		{name: "underscore", id: "release_1"},
+		{name: "empty", id: "", wantErr: true},
+		{name: "spaces", id: "a b", wantErr: true},
+		{name: "special characters", id: "a!b", wantErr: true},
+		{name: "parentheses", id: "task(1)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewIdentifier(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error for %q", tt.id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(id) != tt.id {
+				t.Errorf("expected %q, got %q", tt.id, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package tasklist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Journal is a Storage keeping every change of a TaskList as an Event in an
+// append-only file, one JSON object per line. Loading a TaskList replays the
+// events, so the file is both the state of the TaskList and an audit trail
+// of who changed what and when.
+//
+// Once the journal has grown past a number of events, Save compacts it into
+// a single EventSnapshot holding the current content, dropping the history.
+type Journal struct {
+	mu           sync.Mutex
+	path         string
+	actor        string
+	compactAfter int
+	file         *os.File
+	// events counts the events in the file.
+	events int
+}
+
+// NewJournal returns a Journal backed by the file at path, recording actor
+// as the author of the changes. Save compacts the journal once it holds
+// more than compactAfter events; zero or less never compacts it.
+func NewJournal(path, actor string, compactAfter int) *Journal {
+	return &Journal{
+		path:         path,
+		actor:        actor,
+		compactAfter: compactAfter,
+	}
+}
+
+// Load replays the journal into l and makes l record its changes in the
+// journal from then on. A missing file is not an error and leaves l untouched.
+func (j *Journal) Load(l *TaskList) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events, err := j.replay(l, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.file = file
+	j.events = events
+	l.journal = j
+	return nil
+}
+
+// Replay applies to l the events recorded until the given time, rebuilding
+// the content the TaskList had then. Unlike Load, it leaves l detached from
+// the journal.
+func (j *Journal) Replay(l *TaskList, until time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := j.replay(l, until)
+	return err
+}
+
+// replay applies the events recorded until the given time, or all of them
+// when it is zero, and returns the number of events in the file.
+// The caller holds the write lock of l.
+func (j *Journal) replay(l *TaskList, until time.Time) (int, error) {
+	f, err := os.Open(j.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	events := 0
+	decoder := json.NewDecoder(f)
+	for {
+		var e Event
+		err := decoder.Decode(&e)
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("could not decode event %d of %s: %w", events+1, j.path, err)
+		}
+		events++
+
+		if !until.IsZero() && e.Time.After(until) {
+			continue
+		}
+		if err := l.apply(e); err != nil {
+			return 0, fmt.Errorf("could not replay event %d of %s: %w", events, j.path, err)
+		}
+	}
+}
+
+// Save compacts the journal when it holds more events than allowed.
+// The changes themselves are already written as they are made.
+func (j *Journal) Save(l *TaskList) error {
+	j.mu.Lock()
+	large := j.compactAfter > 0 && j.events > j.compactAfter
+	j.mu.Unlock()
+
+	if !large {
+		return nil
+	}
+	return j.Compact(l)
+}
+
+// Compact replaces the content of the journal with a single EventSnapshot
+// of l, replacing the file atomically.
+func (j *Journal) Compact(l *TaskList) error {
+	// The read lock keeps l from changing, and so from appending to the
+	// journal, while the file is replaced.
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	stored := l.stored()
+	line, err := j.encode(Event{Type: EventSnapshot, Time: l.clock.Now(), Snapshot: &stored})
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomically(j.path, line); err != nil {
+		return err
+	}
+
+	if j.file != nil {
+		j.file.Close()
+	}
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	j.events = 1
+	return nil
+}
+
+// Close closes the journal file. Changes of the TaskList fail afterwards.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+// append writes e at the end of the journal and syncs it to disk.
+func (j *Journal) append(e Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return fmt.Errorf("journal %s is closed", j.path)
+	}
+
+	line, err := j.encode(e)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(line); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.events++
+	return nil
+}
+
+// encode returns e as a line of the journal, signed by its actor.
+func (j *Journal) encode(e Event) ([]byte, error) {
+	e.Actor = j.actor
+	line, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
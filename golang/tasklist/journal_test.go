@@ -0,0 +1,214 @@
+package tasklist
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// readEvents returns the events written in the journal file at path.
+func readEvents(t *testing.T, path string) []Event {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid journal line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func newJournaledTaskList(t *testing.T, journal *Journal) *TaskList {
+	t.Helper()
+
+	taskList := newTestTaskList()
+	if err := journal.Load(taskList); err != nil {
+		t.Fatalf("could not load: %v", err)
+	}
+	t.Cleanup(func() { journal.Close() })
+	return taskList
+}
+
+func TestJournal_recordsAndReplaysChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	taskList := newJournaledTaskList(t, NewJournal(path, "alice", 0))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProjectWithID("abc", "secrets", "Destroy all humans.")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("training", "SOLID")
+	taskList.Check("1", false)
+	taskList.Check("2", false)
+	taskList.Uncheck("2")
+	taskList.SetDeadline("abc", "2020-07-30")
+	taskList.SetPriority("abc", "low")
+	taskList.TagTask("abc", "backend", "urgent")
+	taskList.UntagTask("abc", "urgent")
+	taskList.DeleteTask("1", false)
+	taskList.AddProject("empty")
+	taskList.DeleteProject("empty", false)
+	taskList.EditTask("abc", "Destroy some humans.")
+	taskList.MoveTask("abc", "training")
+	taskList.RenameProject("training", "learning")
+
+	var types []EventType
+	for _, e := range readEvents(t, path) {
+		if e.Actor != "alice" || !e.Time.Equal(testNow) {
+			t.Errorf("expected the event to be made by alice at %v, got %+v", testNow, e)
+		}
+		types = append(types, e.Type)
+	}
+	wantTypes := []EventType{
+		EventProjectAdded, EventTaskAdded, EventTaskAdded, EventProjectAdded, EventTaskAdded,
+		EventTaskChecked, EventTaskChecked, EventTaskUnchecked, EventDeadlineSet, EventPrioritySet, EventTagsAdded, EventTagsRemoved, EventTaskDeleted,
+		EventProjectAdded, EventProjectDeleted, EventTaskEdited, EventTaskMoved, EventProjectRenamed,
+	}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Errorf("expected events %v, got %v", wantTypes, types)
+	}
+
+	replayed := newJournaledTaskList(t, NewJournal(path, "bob", 0))
+	if !reflect.DeepEqual(taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks()) {
+		t.Errorf("replayed projects differ from recorded ones:\n%+v\n%+v", taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks())
+	}
+	if replayed.lastID != taskList.lastID {
+		t.Errorf("expected lastID %d, got %d", taskList.lastID, replayed.lastID)
+	}
+
+	if _, err := replayed.AddTaskToProject("learning", "Coupling and cohesion"); err != nil {
+		t.Fatal(err)
+	}
+	events := readEvents(t, path)
+	if last := events[len(events)-1]; last.Actor != "bob" || last.TaskID != "3" {
+		t.Errorf("expected bob to add task 3, got %+v", last)
+	}
+}
+
+func TestJournal_failedChangesAreNotRecorded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	taskList := newJournaledTaskList(t, NewJournal(path, "alice", 0))
+
+	taskList.AddProject("secrets")
+	taskList.AddProject("secrets")
+	taskList.Check("42", false)
+	taskList.SetDeadline("42", "someday")
+
+	if events := readEvents(t, path); len(events) != 1 {
+		t.Errorf("expected only the first project to be recorded, got %+v", events)
+	}
+}
+
+func TestJournal_Replay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	taskList := newJournaledTaskList(t, NewJournal(path, "alice", 0))
+
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.clock = fixedClock(testNow.Add(time.Hour))
+	taskList.Check("1", false)
+
+	before := newTestTaskList()
+	if err := NewJournal(path, "", 0).Replay(before, testNow); err != nil {
+		t.Fatal(err)
+	}
+	task, _, err := before.GetTask("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.IsDone() {
+		t.Errorf("expected the task not to be done yet at %v", testNow)
+	}
+}
+
+func TestJournal_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	journal := NewJournal(path, "alice", 3)
+	taskList := newJournaledTaskList(t, journal)
+
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.Check("1", false)
+	if err := journal.Save(taskList); err != nil {
+		t.Fatal(err)
+	}
+	if events := readEvents(t, path); len(events) != 3 {
+		t.Fatalf("expected no compaction below the limit, got %d events", len(events))
+	}
+
+	taskList.SetDeadline("1", "2020-07-30")
+	if err := journal.Save(taskList); err != nil {
+		t.Fatal(err)
+	}
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+
+	events := readEvents(t, path)
+	if len(events) != 2 || events[0].Type != EventSnapshot || events[1].Type != EventTaskAdded {
+		t.Fatalf("expected a snapshot followed by the new task, got %+v", events)
+	}
+
+	replayed := newJournaledTaskList(t, NewJournal(path, "alice", 3))
+	if !reflect.DeepEqual(taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks()) {
+		t.Errorf("replayed projects differ from recorded ones:\n%+v\n%+v", taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks())
+	}
+}
+
+func TestJournal_closedJournalRefusesChanges(t *testing.T) {
+	journal := NewJournal(filepath.Join(t.TempDir(), "journal.jsonl"), "alice", 0)
+	taskList := newJournaledTaskList(t, journal)
+	journal.Close()
+
+	if err := taskList.AddProject("secrets"); !errors.Is(err, ErrJournal) {
+		t.Errorf("expected ErrJournal, got %v", err)
+	}
+	if len(taskList.GetProjectsWithTasks()) != 0 {
+		t.Errorf("expected the change not to be made")
+	}
+}
+
+func TestJournal_LoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	if err := os.WriteFile(path, []byte(`{"type":"task-checked","taskID":"1"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewJournal(path, "alice", 0).Load(newTestTaskList()); err == nil {
+		t.Errorf("expected an error for an event on a missing task")
+	}
+}
+
+func TestJournal_recordsRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	taskList := newJournaledTaskList(t, NewJournal(path, "alice", 0))
+
+	taskList.AddProject("secrets")
+	before := taskList.Snapshot()
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	if err := taskList.Restore(before); err != nil {
+		t.Fatal(err)
+	}
+
+	events := readEvents(t, path)
+	if last := events[len(events)-1]; last.Type != EventSnapshot {
+		t.Errorf("expected the restore to be recorded as a snapshot, got %+v", last)
+	}
+	replayed := newJournaledTaskList(t, NewJournal(path, "alice", 0))
+	if !reflect.DeepEqual(taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks()) {
+		t.Errorf("replayed projects differ from restored ones:\n%+v\n%+v", taskList.GetProjectsWithTasks(), replayed.GetProjectsWithTasks())
+	}
+}
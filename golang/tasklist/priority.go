@@ -0,0 +1,62 @@
+package tasklist
+
+import "strings"
+
+// Priority tells how important a task is. The zero Priority means the task has none.
+type Priority int
+
+// The priorities of a task, from the least to the most important.
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var priorityNames = map[Priority]string{
+	PriorityLow:    "low",
+	PriorityMedium: "medium",
+	PriorityHigh:   "high",
+}
+
+// ParsePriority parses one of the high, medium, low and none priorities, ignoring case.
+func ParsePriority(priorityString string) (Priority, error) {
+	s := strings.ToLower(priorityString)
+	if s == "none" {
+		return PriorityNone, nil
+	}
+	for priority, name := range priorityNames {
+		if s == name {
+			return priority, nil
+		}
+	}
+
+	return PriorityNone, NewError(ErrInvalidPriority, "priority \"%s\" is not valid, use high, medium, low or none.\n", priorityString)
+}
+
+// String returns the name of the priority, or an empty string when there is none.
+func (p Priority) String() string {
+	return priorityNames[p]
+}
+
+// cutPriority removes from a task description the words like !high, which
+// give the task a priority, and returns the last priority found.
+func cutPriority(description string) (string, Priority) {
+	priority := PriorityNone
+	words := strings.Fields(description)
+	kept := words[:0]
+	for _, word := range words {
+		if strings.HasPrefix(word, "!") {
+			if p, err := ParsePriority(word[1:]); err == nil && p != PriorityNone {
+				priority = p
+				continue
+			}
+		}
+		kept = append(kept, word)
+	}
+	if priority == PriorityNone {
+		return description, PriorityNone
+	}
+
+	return strings.Join(kept, " "), priority
+}
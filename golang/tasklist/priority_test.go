@@ -0,0 +1,90 @@
+package tasklist
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		priority string
+		want     Priority
+		wantErr  bool
+	}{
+		{priority: "high", want: PriorityHigh},
+		{priority: "Medium", want: PriorityMedium},
+		{priority: "low", want: PriorityLow},
+		{priority: "none", want: PriorityNone},
+		{priority: "urgent", wantErr: true},
+		{priority: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.priority, func(t *testing.T) {
+			got, err := ParsePriority(tt.priority)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPriority) {
+					t.Errorf("expected ErrInvalidPriority for %q, got %v", tt.priority, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("expected %v, got %v, %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestCutPriority(t *testing.T) {
+	tests := []struct {
+		description     string
+		wantDescription string
+		wantPriority    Priority
+	}{
+		{description: "Eat more donuts.", wantDescription: "Eat more donuts.", wantPriority: PriorityNone},
+		{description: "Eat more donuts. !high", wantDescription: "Eat more donuts.", wantPriority: PriorityHigh},
+		{description: "!low Eat more donuts.", wantDescription: "Eat more donuts.", wantPriority: PriorityLow},
+		{description: "Fix the !important bug", wantDescription: "Fix the !important bug", wantPriority: PriorityNone},
+		{description: "Eat  more  donuts. !none", wantDescription: "Eat  more  donuts. !none", wantPriority: PriorityNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			description, priority := cutPriority(tt.description)
+			if description != tt.wantDescription || priority != tt.wantPriority {
+				t.Errorf("expected %q with %v, got %q with %v", tt.wantDescription, tt.wantPriority, description, priority)
+			}
+		})
+	}
+}
+
+func TestTaskList_GetProjectsWithTasksByPriority(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans. !high")
+	taskList.AddTaskToProject("secrets", "Buy a cat. !low")
+	taskList.AddTaskToProject("secrets", "Hide the evidence. !high")
+	taskList.AddTaskToProject("secrets", "Feed the cat.")
+	taskList.SetPriority("3", "medium")
+	taskList.SetDeadline("2", "2024-05-10")
+	taskList.SetDeadline("4", "2024-05-02")
+	taskList.SetDeadline("5", "2024-05-03")
+
+	var got []Identifier
+	for _, task := range taskList.GetProjectsWithTasksByPriority()[0].Tasks {
+		got = append(got, task.GetID())
+	}
+
+	want := []Identifier{"4", "2", "3", "5", "1"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if err := taskList.SetPriority("1", "urgent"); !errors.Is(err, ErrInvalidPriority) {
+		t.Errorf("expected ErrInvalidPriority, got %v", err)
+	}
+}
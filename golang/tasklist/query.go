@@ -0,0 +1,319 @@
+package tasklist
+
+import (
+	"slices"
+	"strings"
+)
+
+// Query selects tasks by their fields, as parsed by TaskList.ParseQuery.
+type Query struct {
+	match matcher
+}
+
+// matcher reports whether a task of the given project is selected.
+type matcher func(task *Task, projectName ProjectName) bool
+
+// Matches reports whether task, in the given project, is selected by q.
+func (q *Query) Matches(task *Task, projectName ProjectName) bool {
+	return q.match(task, projectName)
+}
+
+// queryToken is a word of a query, with its offset in the query line so
+// that errors can point at it.
+type queryToken struct {
+	text string
+	pos  int
+}
+
+// queryParser parses a query by recursive descent:
+//
+//	or      = and { "OR" and }
+//	and     = not { [ "AND" ] not }
+//	not     = "NOT" not | primary
+//	primary = "(" or ")" | term
+type queryParser struct {
+	line   string
+	tokens []queryToken
+	next   int
+	clock  Clock
+}
+
+// ParseQuery parses a query from its words, as typed on a command line.
+// A task is selected when it matches every term, unless the terms are
+// combined with OR, negated with NOT or grouped with parentheses:
+//
+//	project:training     the task is in the project, ignoring case
+//	done:true            the task is done, or not with done:false
+//	due<2024-06-01       the task has a deadline before the date; also
+//	                     due<=, due>, due>=, and due: for an exact date,
+//	                     which can be relative like tomorrow or +3d
+//	due:none             the task has no deadline
+//	tag:urgent, #urgent  the task has the tag
+//	priority:high        the task has the priority, or a higher one with
+//	                     priority>=medium
+//	id:42                the task has the ID
+//	donuts               the description contains the word, ignoring case
+//
+// The operators AND, OR and NOT are written in capitals, so that "and",
+// "or" and "not" can be searched for in descriptions.
+func (l *TaskList) ParseQuery(words []string) (*Query, error) {
+	p := &queryParser{
+		line:   strings.Join(words, " "),
+		tokens: tokenizeQuery(words),
+		clock:  l.clock,
+	}
+	if len(p.tokens) == 0 {
+		return nil, NewError(ErrInvalidQuery, "query is empty.\n")
+	}
+
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.next < len(p.tokens) {
+		return nil, p.errorAt(p.tokens[p.next].pos, "unexpected \"%s\"", p.tokens[p.next].text)
+	}
+	return &Query{match: match}, nil
+}
+
+// tokenizeQuery splits the parentheses from the words of a query.
+func tokenizeQuery(words []string) []queryToken {
+	var tokens []queryToken
+	pos := 0
+	for _, word := range words {
+		start, end := pos, pos+len(word)
+		for strings.HasPrefix(word, "(") {
+			tokens = append(tokens, queryToken{text: "(", pos: start})
+			word = word[1:]
+			start++
+		}
+		var closing []queryToken
+		for strings.HasSuffix(word, ")") {
+			word = word[:len(word)-1]
+			closing = append(closing, queryToken{text: ")", pos: start + len(word)})
+		}
+		if word != "" {
+			tokens = append(tokens, queryToken{text: word, pos: start})
+		}
+		for i := len(closing) - 1; i >= 0; i-- {
+			tokens = append(tokens, closing[i])
+		}
+		pos = end + 1
+	}
+	return tokens
+}
+
+// errorAt returns an ErrInvalidQuery showing the query line with a caret
+// under the offending position.
+func (p *queryParser) errorAt(pos int, format string, a ...any) error {
+	return NewError(ErrInvalidQuery, "query is not valid, "+format+":\n    %s\n    %s^\n",
+		append(a, p.line, strings.Repeat(" ", pos))...)
+}
+
+// peek returns the text of the next token, or "" at the end of the query.
+func (p *queryParser) peek() string {
+	if p.next == len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.next].text
+}
+
+func (p *queryParser) parseOr() (matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.next++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orMatcher(left, right)
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (matcher, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for next := p.peek(); next != "" && next != "OR" && next != ")"; next = p.peek() {
+		if next == "AND" {
+			p.next++
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andMatcher(left, right)
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (matcher, error) {
+	if p.peek() != "NOT" {
+		return p.parsePrimary()
+	}
+	p.next++
+	negated, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	return func(task *Task, projectName ProjectName) bool {
+		return !negated(task, projectName)
+	}, nil
+}
+
+func (p *queryParser) parsePrimary() (matcher, error) {
+	if p.next == len(p.tokens) {
+		return nil, p.errorAt(len(p.line), "expected a term after \"%s\"", p.tokens[p.next-1].text)
+	}
+	token := p.tokens[p.next]
+	p.next++
+
+	switch token.text {
+	case "(":
+		match, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, p.errorAt(token.pos, "missing \")\" closing this \"(\"")
+		}
+		p.next++
+		return match, nil
+	case ")", "AND", "OR":
+		return nil, p.errorAt(token.pos, "unexpected \"%s\"", token.text)
+	}
+	return p.parseTerm(token)
+}
+
+// queryFields are the fields a term can select tasks by.
+var queryFields = []string{"project", "done", "due", "tag", "priority", "id"}
+
+// parseTerm parses a single condition on a field, or a text to look for
+// in the description. A quoted term with spaces is a condition only when it
+// starts with a known field, as in project:"Q3 launch", so that a text like
+// "note: call back" is looked for as a whole.
+func (p *queryParser) parseTerm(token queryToken) (matcher, error) {
+	if strings.HasPrefix(token.text, "#") {
+		return p.parseField(token, "tag", ":", token.text)
+	}
+
+	i := strings.IndexAny(token.text, ":<>=")
+	if i <= 0 || (strings.Contains(token.text, " ") && !slices.Contains(queryFields, strings.ToLower(token.text[:i]))) {
+		text := strings.ToLower(token.text)
+		return func(task *Task, _ ProjectName) bool {
+			return strings.Contains(strings.ToLower(task.description), text)
+		}, nil
+	}
+
+	field := strings.ToLower(token.text[:i])
+	rest := token.text[i:]
+	var operator, value string
+	for _, op := range []string{"<=", ">=", "<", ">", "=", ":"} {
+		if strings.HasPrefix(rest, op) {
+			operator, value = op, rest[len(op):]
+			break
+		}
+	}
+	if operator == "=" {
+		operator = ":"
+	}
+	if value == "" {
+		return nil, p.errorAt(token.pos, "missing a value after \"%s\"", token.text)
+	}
+
+	return p.parseField(token, field, operator, value)
+}
+
+func (p *queryParser) parseField(token queryToken, field, operator, value string) (matcher, error) {
+	if operator != ":" && field != "due" && field != "priority" {
+		return nil, p.errorAt(token.pos, "%s cannot be compared with \"%s\"", field, operator)
+	}
+
+	switch field {
+	case "project":
+		return func(_ *Task, projectName ProjectName) bool {
+			return strings.EqualFold(string(projectName), value)
+		}, nil
+	case "done":
+		var done bool
+		switch strings.ToLower(value) {
+		case "true", "yes":
+			done = true
+		case "false", "no":
+		default:
+			return nil, p.errorAt(token.pos, "done must be true or false")
+		}
+		return func(task *Task, _ ProjectName) bool {
+			return task.done == done
+		}, nil
+	case "due":
+		deadline, err := ParseDeadline(value, p.clock)
+		if err != nil {
+			return nil, p.errorAt(token.pos, "\"%s\" is not a valid date", value)
+		}
+		if deadline.IsEmpty() && operator != ":" {
+			return nil, p.errorAt(token.pos, "tasks without a deadline cannot be compared")
+		}
+		return func(task *Task, _ ProjectName) bool {
+			if deadline.IsEmpty() || task.deadline.IsEmpty() {
+				return deadline.IsEmpty() && task.deadline.IsEmpty()
+			}
+			return compareWith(operator, task.deadline.Date().Compare(deadline.in(p.clock.Now().Location()).Date()))
+		}, nil
+	case "tag":
+		tag, err := NewTag(value)
+		if err != nil {
+			return nil, p.errorAt(token.pos, "\"%s\" is not a valid tag", value)
+		}
+		return func(task *Task, _ ProjectName) bool {
+			return task.HasTag(tag)
+		}, nil
+	case "priority":
+		priority, err := ParsePriority(value)
+		if err != nil {
+			return nil, p.errorAt(token.pos, "priority must be high, medium, low or none")
+		}
+		return func(task *Task, _ ProjectName) bool {
+			return compareWith(operator, int(task.priority)-int(priority))
+		}, nil
+	case "id":
+		return func(task *Task, _ ProjectName) bool {
+			return string(task.id) == value
+		}, nil
+	}
+
+	return nil, p.errorAt(token.pos, "unknown field \"%s\", use one of %s", field, strings.Join(queryFields, ", "))
+}
+
+// compareWith reports whether the result of a comparison, negative, zero
+// or positive, satisfies operator.
+func compareWith(operator string, c int) bool {
+	switch operator {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return c == 0
+}
+
+func andMatcher(left, right matcher) matcher {
+	return func(task *Task, projectName ProjectName) bool {
+		return left(task, projectName) && right(task, projectName)
+	}
+}
+
+func orMatcher(left, right matcher) matcher {
+	return func(task *Task, projectName ProjectName) bool {
+		return left(task, projectName) || right(task, projectName)
+	}
+}
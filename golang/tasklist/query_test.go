@@ -0,0 +1,171 @@
+package tasklist
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newQueryTestTaskList returns a TaskList with a few tasks of every kind.
+func newQueryTestTaskList() *TaskList {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddProject("empty")
+	taskList.AddTaskToProject("secrets", "Eat more donuts. #food !low")
+	taskList.AddTaskToProject("secrets", "Destroy all humans. !high")
+	taskList.AddTaskToProject("training", "SOLID #urgent")
+	taskList.AddTaskToProject("training", "Outside-In TDD #urgent !medium")
+	taskList.SetDeadline("1", "2024-04-30")
+	taskList.SetDeadline("3", "2024-05-20")
+	taskList.SetDeadline("4", "2024-06-10")
+	taskList.Check("1", false)
+	return taskList
+}
+
+// selectedIDs returns the IDs of the tasks of l selected by the query.
+func selectedIDs(t *testing.T, l *TaskList, query string) []string {
+	t.Helper()
+
+	q, err := l.ParseQuery(strings.Fields(query))
+	if err != nil {
+		t.Fatalf("could not parse %q: %v", query, err)
+	}
+	ids := []string{}
+	for _, projectWithTasks := range l.Filter(q).GetProjectsWithTasks() {
+		for _, task := range projectWithTasks.Tasks {
+			ids = append(ids, string(task.GetID()))
+		}
+	}
+	return ids
+}
+
+func TestTaskList_ParseQuery(t *testing.T) {
+	taskList := newQueryTestTaskList()
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"project:training", []string{"3", "4"}},
+		{"project:Training done:false", []string{"3", "4"}},
+		{"done:true", []string{"1"}},
+		{"due<2024-06-01", []string{"1", "3"}},
+		{"due<=2024-05-20", []string{"1", "3"}},
+		{"due>2024-05-20", []string{"4"}},
+		{"due:none", []string{"2"}},
+		{"due<+30d", []string{"1", "3"}},
+		{"tag:urgent", []string{"3", "4"}},
+		{"#food", []string{"1"}},
+		{"priority:high", []string{"2"}},
+		{"priority>=medium", []string{"2", "4"}},
+		{"id:3", []string{"3"}},
+		{"DONUTS", []string{"1"}},
+		{"project:training done:false due<2024-06-01 tag:urgent", []string{"3"}},
+		{"tag:urgent AND due>2024-06-01", []string{"4"}},
+		{"#food OR priority:high", []string{"1", "2"}},
+		{"NOT project:secrets", []string{"3", "4"}},
+		{"NOT NOT project:secrets", []string{"1", "2"}},
+		{"(project:secrets OR tag:urgent) done:false", []string{"2", "3", "4"}},
+		{"project:secrets OR tag:urgent done:false", []string{"1", "2", "3", "4"}},
+		{"NOT (donuts OR humans)", []string{"3", "4"}},
+		{"nothing", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := selectedIDs(t, taskList, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected tasks %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskList_ParseQuery_textWithSpaces(t *testing.T) {
+	taskList := newQueryTestTaskList()
+
+	q, err := taskList.ParseQuery([]string{"all humans"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, projectName, _ := taskList.GetTask("2")
+	if !q.Matches(task, projectName) {
+		t.Errorf("expected a quoted text to be looked for as a whole")
+	}
+}
+
+func TestTaskList_ParseQuery_projectWithSpaces(t *testing.T) {
+	taskList := newQueryTestTaskList()
+	taskList.AddProject("Q3 launch")
+	taskList.AddTaskToProject("Q3 launch", "Write the note: call back.")
+
+	q, err := taskList.ParseQuery([]string{"project:q3 launch"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, projectName, _ := taskList.GetTask("5")
+	if !q.Matches(task, projectName) {
+		t.Errorf("expected a project name with spaces to be selected by project:")
+	}
+
+	q, err = taskList.ParseQuery([]string{"note: call"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Matches(task, projectName) {
+		t.Errorf("expected a text with spaces and a colon to be looked for in the description")
+	}
+}
+
+func TestTaskList_ParseQuery_errors(t *testing.T) {
+	taskList := newQueryTestTaskList()
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "query is empty.\n"},
+		{"owner:me", "query is not valid, unknown field \"owner\", use one of project, done, due, tag, priority, id:\n    owner:me\n    ^\n"},
+		{"done:false OR", "query is not valid, expected a term after \"OR\":\n    done:false OR\n                 ^\n"},
+		{"done:false ) tag:urgent", "query is not valid, unexpected \")\":\n    done:false ) tag:urgent\n               ^\n"},
+		{"(done:false tag:urgent", "query is not valid, missing \")\" closing this \"(\":\n    (done:false tag:urgent\n    ^\n"},
+		{"tag:urgent due<someday", "query is not valid, \"someday\" is not a valid date:\n    tag:urgent due<someday\n               ^\n"},
+		{"done:maybe", "query is not valid, done must be true or false:\n    done:maybe\n    ^\n"},
+		{"project<secrets", "query is not valid, project cannot be compared with \"<\":\n    project<secrets\n    ^\n"},
+		{"due<none", "query is not valid, tasks without a deadline cannot be compared:\n    due<none\n    ^\n"},
+		{"tag:", "query is not valid, missing a value after \"tag:\":\n    tag:\n    ^\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := taskList.ParseQuery(strings.Fields(tt.query))
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Fatalf("expected ErrInvalidQuery, got %v", err)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestTaskList_Filter(t *testing.T) {
+	taskList := newQueryTestTaskList()
+	q, err := taskList.ParseQuery([]string{"done:false"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filtered := taskList.Filter(q)
+	var projectNames []ProjectName
+	for _, projectWithTasks := range filtered.GetProjectsWithTasks() {
+		projectNames = append(projectNames, projectWithTasks.ProjectName)
+	}
+	if want := []ProjectName{"secrets", "training"}; !reflect.DeepEqual(projectNames, want) {
+		t.Errorf("expected the projects without selected tasks to be left out, got %v", projectNames)
+	}
+
+	filtered.Check("2", false)
+	if task, _, _ := taskList.GetTask("2"); task.IsDone() {
+		t.Errorf("expected changes to the filtered TaskList not to change the original one")
+	}
+}
@@ -0,0 +1,178 @@
+package tasklist
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// noRecurrence is the recurrence string making a task stop repeating.
+const noRecurrence = "none"
+
+var (
+	// everyPattern matches recurrences like "every 2 weeks" or "every month on 15".
+	everyPattern = regexp.MustCompile(`^every (?:(\d+) )?(day|week|month|year)s?(?: on (\w+))?$`)
+	// adverbPattern matches recurrences like "weekly" or "monthly on 1".
+	adverbPattern = regexp.MustCompile(`^(daily|weekly|monthly|yearly)(?: on (\w+))?$`)
+)
+
+// recurrenceUnit is the unit of the interval between two occurrences.
+type recurrenceUnit string
+
+const (
+	recurrenceDays   = recurrenceUnit("day")
+	recurrenceWeeks  = recurrenceUnit("week")
+	recurrenceMonths = recurrenceUnit("month")
+	recurrenceYears  = recurrenceUnit("year")
+)
+
+// Recurrence is the rule giving the deadlines of the occurrences of a
+// repeating task, like "every monday" or "monthly on 1". The zero
+// Recurrence means the task does not repeat.
+type Recurrence struct {
+	every int
+	unit  recurrenceUnit
+	// weekday is the day of the week of the occurrences repeating by weeks,
+	// when onWeekday is set.
+	weekday   time.Weekday
+	onWeekday bool
+	// monthDay is the day of the month of the occurrences repeating by
+	// months, or zero for the day of the previous occurrence.
+	monthDay int
+}
+
+// ParseRecurrence parses a recurrence rule, ignoring case:
+//
+//	every day, daily             every day, or every 3 days
+//	every week, weekly           every week, or every 2 weeks
+//	every monday, every mon      every week on monday, or every 2 weeks on monday
+//	every month, monthly         every month, or every 3 months
+//	monthly on 1                 every month on its first day, or every 3 months on 1
+//	every year, yearly           every year, or every 2 years
+//
+// The "none" recurrence is the empty Recurrence, for tasks that do not repeat.
+func ParseRecurrence(recurrenceString string) (Recurrence, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(recurrenceString), " "))
+	if s == noRecurrence {
+		return Recurrence{}, nil
+	}
+
+	invalid := NewError(ErrInvalidRecurrence, "recurrence \"%s\" is not valid, use a rule like every monday, every 2 weeks, monthly on 1 or none.\n", recurrenceString)
+
+	r := Recurrence{every: 1}
+	if strings.HasPrefix(s, "every ") {
+		if weekday, ok := parseWeekday(strings.TrimPrefix(s, "every ")); ok {
+			r.unit, r.weekday, r.onWeekday = recurrenceWeeks, weekday, true
+			return r, nil
+		}
+	}
+
+	var on string
+	if submatches := everyPattern.FindStringSubmatch(s); submatches != nil {
+		if submatches[1] != "" {
+			n, err := strconv.Atoi(submatches[1])
+			if err != nil || n < 1 {
+				return Recurrence{}, invalid
+			}
+			r.every = n
+		}
+		r.unit, on = recurrenceUnit(submatches[2]), submatches[3]
+	} else if submatches := adverbPattern.FindStringSubmatch(s); submatches != nil {
+		r.unit = map[string]recurrenceUnit{
+			"daily":   recurrenceDays,
+			"weekly":  recurrenceWeeks,
+			"monthly": recurrenceMonths,
+			"yearly":  recurrenceYears,
+		}[submatches[1]]
+		on = submatches[2]
+	} else {
+		return Recurrence{}, invalid
+	}
+
+	if on == "" {
+		return r, nil
+	}
+	switch r.unit {
+	case recurrenceWeeks:
+		weekday, ok := parseWeekday(on)
+		if !ok {
+			return Recurrence{}, invalid
+		}
+		r.weekday, r.onWeekday = weekday, true
+	case recurrenceMonths:
+		day, err := strconv.Atoi(on)
+		if err != nil || day < 1 || day > 31 {
+			return Recurrence{}, invalid
+		}
+		r.monthDay = day
+	default:
+		return Recurrence{}, invalid
+	}
+	return r, nil
+}
+
+// IsEmpty reports whether the task does not repeat.
+func (r Recurrence) IsEmpty() bool {
+	return r.every == 0
+}
+
+// String returns the rule in the form parsed by ParseRecurrence, or an
+// empty string when the task does not repeat.
+func (r Recurrence) String() string {
+	switch {
+	case r.IsEmpty():
+		return ""
+	case r.onWeekday && r.every == 1:
+		return "every " + strings.ToLower(r.weekday.String())
+	case r.onWeekday:
+		return fmt.Sprintf("every %d weeks on %s", r.every, strings.ToLower(r.weekday.String()))
+	case r.monthDay > 0 && r.every == 1:
+		return fmt.Sprintf("monthly on %d", r.monthDay)
+	case r.monthDay > 0:
+		return fmt.Sprintf("every %d months on %d", r.every, r.monthDay)
+	case r.every == 1:
+		return "every " + string(r.unit)
+	}
+	return fmt.Sprintf("every %d %ss", r.every, r.unit)
+}
+
+// next returns the day of the occurrence following the one due on date.
+func (r Recurrence) next(date time.Time) time.Time {
+	switch r.unit {
+	case recurrenceDays:
+		return date.AddDate(0, 0, r.every)
+	case recurrenceWeeks:
+		if !r.onWeekday {
+			return date.AddDate(0, 0, 7*r.every)
+		}
+		days := (int(r.weekday) - int(date.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return date.AddDate(0, 0, days+7*(r.every-1))
+	case recurrenceMonths:
+		if r.monthDay == 0 {
+			return addMonths(date, r.every)
+		}
+		next := dayOfMonth(date.Year(), date.Month(), r.monthDay)
+		if !next.After(date) {
+			next = dayOfMonth(date.Year(), date.Month()+1, r.monthDay)
+		}
+		return dayOfMonth(next.Year(), next.Month()+time.Month(r.every-1), r.monthDay)
+	case recurrenceYears:
+		return addMonths(date, 12*r.every)
+	}
+	return date
+}
+
+// nextDeadline returns the deadline of the occurrence following the one due
+// on date, skipping the occurrences before today.
+func (r Recurrence) nextDeadline(date, today time.Time) Deadline {
+	next := r.next(date)
+	for next.Before(today) {
+		next = r.next(next)
+	}
+	return Deadline{date: next}
+}
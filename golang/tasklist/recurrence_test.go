@@ -0,0 +1,191 @@
+package tasklist
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		recurrence string
+		want       string
+		wantErr    bool
+	}{
+		{recurrence: "every monday", want: "every monday"},
+		{recurrence: "Every Mon", want: "every monday"},
+		{recurrence: "every week on friday", want: "every friday"},
+		{recurrence: "every 2 weeks", want: "every 2 weeks"},
+		{recurrence: "every 2 weeks on fri", want: "every 2 weeks on friday"},
+		{recurrence: "every day", want: "every day"},
+		{recurrence: "every 3 days", want: "every 3 days"},
+		{recurrence: "daily", want: "every day"},
+		{recurrence: "weekly on sunday", want: "every sunday"},
+		{recurrence: "monthly", want: "every month"},
+		{recurrence: "monthly on 1", want: "monthly on 1"},
+		{recurrence: "every month on 1", want: "monthly on 1"},
+		{recurrence: "every 3 months on 15", want: "every 3 months on 15"},
+		{recurrence: "yearly", want: "every year"},
+		{recurrence: "none", want: ""},
+		{recurrence: "every 0 days", wantErr: true},
+		{recurrence: "monthly on 32", wantErr: true},
+		{recurrence: "monthly on monday", wantErr: true},
+		{recurrence: "every day on monday", wantErr: true},
+		{recurrence: "sometimes", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.recurrence, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.recurrence)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRecurrence) {
+					t.Errorf("expected ErrInvalidRecurrence for %q, got %v", tt.recurrence, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := recurrence.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecurrence_nextDeadline(t *testing.T) {
+	// testNow is a wednesday.
+	tests := []struct {
+		recurrence string
+		due        string
+		want       string
+	}{
+		{recurrence: "every monday", due: "2024-05-06", want: "2024-05-13"},
+		{recurrence: "every monday", due: "2024-05-01", want: "2024-05-06"},
+		{recurrence: "every 2 weeks on friday", due: "2024-05-03", want: "2024-05-17"},
+		{recurrence: "every 2 weeks", due: "2024-05-01", want: "2024-05-15"},
+		{recurrence: "every 3 days", due: "2024-04-20", want: "2024-05-02"},
+		{recurrence: "monthly on 1", due: "2024-05-01", want: "2024-06-01"},
+		{recurrence: "monthly on 1", due: "2024-05-20", want: "2024-06-01"},
+		{recurrence: "monthly on 31", due: "2024-05-31", want: "2024-06-30"},
+		{recurrence: "monthly on 31", due: "2024-06-30", want: "2024-07-31"},
+		{recurrence: "every 2 months on 15", due: "2024-05-10", want: "2024-06-15"},
+		{recurrence: "every month", due: "2024-05-31", want: "2024-06-30"},
+		{recurrence: "yearly", due: "2024-02-29", want: "2025-02-28"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.recurrence+" after "+tt.due, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.recurrence)
+			if err != nil {
+				t.Fatal(err)
+			}
+			due, err := time.Parse(timeFormat, tt.due)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := recurrence.nextDeadline(due, dayOf(testNow)).String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskList_checkRecurringTask(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Water the plants. #home !high")
+	taskList.SetDeadline("1", "2024-05-06")
+	if err := taskList.SetRecurrence("1", "every monday"); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := taskList.Check("1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == nil {
+		t.Fatalf("expected the next occurrence to be added")
+	}
+	if next.GetID() != "2" || next.GetDescription() != "Water the plants." || next.IsDone() {
+		t.Errorf("expected an open copy of the task with a new ID, got %+v", next)
+	}
+	if got := next.GetDeadline().String(); got != "2024-05-13" {
+		t.Errorf("expected the next occurrence to be due 2024-05-13, got %s", got)
+	}
+	if next.GetPriority() != PriorityHigh || len(next.GetTags()) != 1 || next.GetRecurrence().String() != "every monday" {
+		t.Errorf("expected the next occurrence to keep the priority, tags and recurrence, got %+v", next)
+	}
+
+	task, _, _ := taskList.GetTask("1")
+	if !task.IsDone() || !task.GetRecurrence().IsEmpty() {
+		t.Errorf("expected the checked task to be done and to stop repeating")
+	}
+
+	if err := taskList.Uncheck("1"); err != nil {
+		t.Fatal(err)
+	}
+	if next, _ := taskList.Check("1", false); next != nil {
+		t.Errorf("expected a task checked again not to repeat again, got %+v", next)
+	}
+}
+
+func TestTaskList_checkRecurringTaskWithoutDeadline(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Pay the rent.")
+	taskList.SetRecurrence("1", "monthly on 1")
+
+	next, err := taskList.Check("1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := next.GetDeadline().String(); got != "2024-06-01" {
+		t.Errorf("expected the next occurrence to follow today, got %q", got)
+	}
+}
+
+func TestTaskList_checkRecurringTaskKeepsTheTimeOfDay(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Call mum.")
+	taskList.SetDeadline("1", "2024-05-06T19:30 UTC")
+	taskList.SetRecurrence("1", "every monday")
+
+	next, err := taskList.Check("1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := next.GetDeadline().String(); got != "2024-05-13T19:30 UTC" {
+		t.Errorf("expected the next occurrence at the same time, got %q", got)
+	}
+}
+
+func TestTaskList_checkRecurringTaskAcrossDaylightSavingTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// New York moves its clocks on March 8, 2026, and Madrid on March 29.
+	now := time.Date(2026, time.March, 1, 9, 0, 0, 0, newYork)
+	taskList := NewTaskList(newTestTaskList().idGenerator, WithClock(fixedClock(now)), WithLocation(newYork))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Call mum.")
+	taskList.SetDeadline("1", "2026-03-02T17:00 Europe/Madrid")
+	taskList.SetRecurrence("1", "every week")
+
+	id := "1"
+	for _, want := range []string{"2026-03-09T16:00Z", "2026-03-16T16:00Z", "2026-03-23T16:00Z", "2026-03-30T15:00Z"} {
+		next, err := taskList.Check(id, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		deadline := next.GetDeadline()
+		if got := deadline.Time().UTC().Format(dateTimeOffsetFormat); got != want {
+			t.Errorf("expected the occurrence after task %s at %s, 17:00 in Madrid, got %s", id, want, got)
+		}
+		if got := deadline.String(); got[10:] != "T17:00 Europe/Madrid" {
+			t.Errorf("expected the occurrence to keep the time zone it was given in, got %q", got)
+		}
+		id = string(next.GetID())
+	}
+}
@@ -0,0 +1,143 @@
+package tasklist
+
+import (
+	"slices"
+)
+
+// ProjectName is the name of a project.
+type ProjectName string
+
+// TaskRepository stores the projects of a TaskList and their tasks.
+// A TaskList serializes the calls to its repository and copies the tasks it
+// returns, so implementations need neither locking nor defensive copies.
+type TaskRepository interface {
+	// AddProject creates an empty project with the given name.
+	AddProject(name ProjectName)
+	// HasProject reports whether a project with the given name exists.
+	HasProject(name ProjectName) bool
+	// DeleteProject removes a project together with its tasks.
+	DeleteProject(name ProjectName) error
+	// RenameProject gives a project, keeping its tasks, a name not used by another project.
+	RenameProject(oldName, newName ProjectName) error
+	// Projects returns the project names sorted alphabetically.
+	Projects() []ProjectName
+	// Tasks returns the tasks of a project in insertion order.
+	Tasks(name ProjectName) []*Task
+	// FindTask returns the task with the given ID and the project holding it.
+	FindTask(id Identifier) (*Task, ProjectName, error)
+	// SaveTask stores the task in a project, replacing any task with the same ID.
+	SaveTask(name ProjectName, task *Task) error
+	// DeleteTask removes the task with the given ID from its project.
+	DeleteTask(id Identifier) error
+}
+
+// inMemoryTaskRepository is the default TaskRepository, keeping tasks in a map.
+type inMemoryTaskRepository struct {
+	projectTasks map[ProjectName][]*Task
+}
+
+// NewInMemoryTaskRepository returns an empty TaskRepository kept in memory.
+func NewInMemoryTaskRepository() TaskRepository {
+	return &inMemoryTaskRepository{
+		projectTasks: make(map[ProjectName][]*Task),
+	}
+}
+
+func (r *inMemoryTaskRepository) AddProject(name ProjectName) {
+	r.projectTasks[name] = make([]*Task, 0)
+}
+
+func (r *inMemoryTaskRepository) HasProject(name ProjectName) bool {
+	_, ok := r.projectTasks[name]
+	return ok
+}
+
+func (r *inMemoryTaskRepository) DeleteProject(name ProjectName) error {
+	if !r.HasProject(name) {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", name)
+	}
+
+	delete(r.projectTasks, name)
+	return nil
+}
+
+func (r *inMemoryTaskRepository) RenameProject(oldName, newName ProjectName) error {
+	tasks, ok := r.projectTasks[oldName]
+	if !ok {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", oldName)
+	}
+	if r.HasProject(newName) {
+		return NewError(ErrDuplicateProject, "project \"%s\" already exists.\n", newName)
+	}
+
+	delete(r.projectTasks, oldName)
+	r.projectTasks[newName] = tasks
+	return nil
+}
+
+func (r *inMemoryTaskRepository) Projects() []ProjectName {
+	return getSortedProjectNames(r.projectTasks)
+}
+
+func (r *inMemoryTaskRepository) Tasks(name ProjectName) []*Task {
+	return r.projectTasks[name]
+}
+
+func (r *inMemoryTaskRepository) FindTask(id Identifier) (*Task, ProjectName, error) {
+	for name, tasks := range r.projectTasks {
+		for _, task := range tasks {
+			if task.GetID() == id {
+				return task, name, nil
+			}
+		}
+	}
+
+	return nil, "", NewError(ErrTaskNotFound, "task with ID \"%v\" not found.\n", id)
+}
+
+func (r *inMemoryTaskRepository) SaveTask(name ProjectName, task *Task) error {
+	tasks, ok := r.projectTasks[name]
+	if !ok {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", name)
+	}
+
+	for i, existing := range tasks {
+		if existing.GetID() == task.GetID() {
+			tasks[i] = task
+			return nil
+		}
+	}
+
+	r.projectTasks[name] = append(tasks, task)
+	return nil
+}
+
+func (r *inMemoryTaskRepository) DeleteTask(id Identifier) error {
+	for name, tasks := range r.projectTasks {
+		for i, task := range tasks {
+			if task.GetID() == id {
+				r.projectTasks[name] = slices.Delete(tasks, i, i+1)
+				return nil
+			}
+		}
+	}
+
+	return NewError(ErrTaskNotFound, "task with ID \"%v\" not found.\n", id)
+}
+
+// getSortedProjectNames returns all project names sorted, given a map m
+// of (key)ProjectName and (values) slice of tasks
+func getSortedProjectNames(projectTasks map[ProjectName][]*Task) []ProjectName {
+	projectNames := convertMapOfProjectNamesToSliceOfProjectNames(projectTasks)
+	slices.Sort(projectNames)
+
+	return projectNames
+}
+
+func convertMapOfProjectNamesToSliceOfProjectNames(projectTasks map[ProjectName][]*Task) []ProjectName {
+	projectNames := make([]ProjectName, 0, len(projectTasks))
+	for projectName := range projectTasks {
+		projectNames = append(projectNames, projectName)
+	}
+	return projectNames
+}
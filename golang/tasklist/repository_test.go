@@ -0,0 +1,106 @@
+package tasklist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInMemoryTaskRepository_SaveTask(t *testing.T) {
+	repository := NewInMemoryTaskRepository()
+	repository.AddProject("secrets")
+
+	first, _ := NewTask("1", "Eat more donuts.", false)
+	second, _ := NewTask("2", "Destroy all humans.", false)
+	if err := repository.SaveTask("secrets", first); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.SaveTask("secrets", second); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _ := NewTask("1", "Eat less donuts.", true)
+	if err := repository.SaveTask("secrets", updated); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Task{updated, second}
+	if got := repository.Tasks("secrets"); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if err := repository.SaveTask("training", first); err == nil {
+		t.Errorf("expected an error when saving into an unknown project")
+	}
+}
+
+func TestInMemoryTaskRepository_FindAndDeleteTask(t *testing.T) {
+	repository := NewInMemoryTaskRepository()
+	repository.AddProject("secrets")
+	task, _ := NewTask("1", "Eat more donuts.", false)
+	repository.SaveTask("secrets", task)
+
+	found, project, err := repository.FindTask("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found != task || project != "secrets" {
+		t.Errorf("expected task 1 in secrets, got %+v in %s", found, project)
+	}
+
+	if err := repository.DeleteTask("1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := repository.FindTask("1"); err == nil {
+		t.Errorf("expected an error when finding a deleted task")
+	}
+	if err := repository.DeleteTask("1"); err == nil {
+		t.Errorf("expected an error when deleting an unknown task")
+	}
+}
+
+func TestInMemoryTaskRepository_RenameProject(t *testing.T) {
+	repository := NewInMemoryTaskRepository()
+	repository.AddProject("secrets")
+	repository.AddProject("training")
+	task, _ := NewTask("1", "Eat more donuts.", true)
+	repository.SaveTask("secrets", task)
+
+	if err := repository.RenameProject("secrets", "confidential"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ProjectName{"confidential", "training"}
+	if got := repository.Projects(); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, name, err := repository.FindTask("1"); err != nil || name != "confidential" {
+		t.Errorf("expected task 1 in confidential, got %q, %v", name, err)
+	}
+	if err := repository.RenameProject("confidential", "training"); err == nil {
+		t.Errorf("expected an error when renaming to a used name")
+	}
+	if err := repository.RenameProject("secrets", "other"); err == nil {
+		t.Errorf("expected an error when renaming an unknown project")
+	}
+}
+
+func TestTaskList_WithRepository(t *testing.T) {
+	repository := NewInMemoryTaskRepository()
+	taskList := NewTaskList(func(id int64) string { return "42" }, WithRepository(repository))
+
+	taskList.AddProject("secrets")
+	if _, err := taskList.AddTaskToProject("secrets", "Eat more donuts."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := taskList.Check("42", false); err != nil {
+		t.Fatal(err)
+	}
+
+	task, _, err := repository.FindTask("42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !task.IsDone() {
+		t.Errorf("expected the task stored in the repository to be done")
+	}
+}
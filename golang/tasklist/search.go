@@ -0,0 +1,180 @@
+package tasklist
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SearchMode is how Search matches its terms against task descriptions.
+type SearchMode int
+
+const (
+	// SearchText matches the terms as plain text.
+	SearchText SearchMode = iota
+	// SearchRegexp matches the terms as regular expressions.
+	SearchRegexp
+	// SearchFuzzy matches the terms when their characters appear in order,
+	// so that "dnts" finds "donuts".
+	SearchFuzzy
+)
+
+// The scores of a term found in a description, the best match of a term
+// counting. A fuzzy match scores below substringScore, less for every
+// character between the ones of the term.
+const (
+	wordScore       = 30
+	wordStartScore  = 20
+	substringScore  = 10
+	fuzzyScoreLimit = substringScore - 1
+)
+
+// SearchResult is a task found by Search, with its project.
+type SearchResult struct {
+	ProjectName ProjectName
+	Task        *Task
+	// Score ranks the results, the higher the better.
+	Score int
+}
+
+// Search returns the tasks of every project whose description matches all
+// the terms, ignoring case. The results are sorted by score, a term found as
+// a whole word scoring more than one found at the start of a word, or in
+// the middle of one; the tasks with the same score are sorted by project.
+func (l *TaskList) Search(terms []string, mode SearchMode) ([]SearchResult, error) {
+	scorers := make([]func(description string) int, 0, len(terms))
+	for _, term := range terms {
+		scorer, err := newScorer(term, mode)
+		if err != nil {
+			return nil, err
+		}
+		scorers = append(scorers, scorer)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var results []SearchResult
+	for _, projectName := range l.repository.Projects() {
+		for _, task := range l.repository.Tasks(projectName) {
+			score, found := 0, len(scorers) > 0
+			for _, scorer := range scorers {
+				termScore := scorer(task.description)
+				if termScore == 0 {
+					found = false
+					break
+				}
+				score += termScore
+			}
+			if found {
+				results = append(results, SearchResult{ProjectName: projectName, Task: task.clone(), Score: score})
+			}
+		}
+	}
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		return b.Score - a.Score
+	})
+
+	return results, nil
+}
+
+// newScorer returns the function scoring a description for term, giving
+// zero when the term is not found.
+func newScorer(term string, mode SearchMode) (func(description string) int, error) {
+	switch mode {
+	case SearchRegexp:
+		re, err := regexp.Compile("(?i)" + term)
+		if err != nil {
+			return nil, NewError(ErrInvalidPattern, "pattern \"%s\" is not a valid regular expression.\n", term)
+		}
+		return func(description string) int {
+			return bestMatchScore(description, re.FindAllStringIndex(description, -1))
+		}, nil
+	case SearchFuzzy:
+		text := substringScorer(term)
+		return func(description string) int {
+			if score := text(description); score > 0 {
+				return score
+			}
+			return fuzzyScore(strings.ToLower(description), strings.ToLower(term))
+		}, nil
+	}
+	return substringScorer(term), nil
+}
+
+// substringScorer returns the function scoring a description for term as plain text.
+func substringScorer(term string) func(description string) int {
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+	return func(description string) int {
+		return bestMatchScore(description, re.FindAllStringIndex(description, -1))
+	}
+}
+
+// bestMatchScore returns the score of the best of the matches, given as
+// start and end offsets in description.
+func bestMatchScore(description string, matches [][]int) int {
+	best := 0
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		startsWord := match[0] == 0 || !isWordByte(description[match[0]-1])
+		endsWord := match[1] == len(description) || !isWordByte(description[match[1]])
+		score := substringScore
+		switch {
+		case startsWord && endsWord:
+			score = wordScore
+		case startsWord:
+			score = wordStartScore
+		}
+		if score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// isWordByte reports whether b is part of a word. Bytes of multibyte
+// characters are, so that words with accents are not split.
+func isWordByte(b byte) bool {
+	r := rune(b)
+	return r >= 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// fuzzyScore returns the score of the characters of term appearing in order
+// in description, the closest together the better, or zero if they do not.
+func fuzzyScore(description, term string) int {
+	text, pattern := []rune(description), []rune(term)
+	if len(pattern) == 0 {
+		return 0
+	}
+
+	shortest := -1
+	for start := range text {
+		if text[start] != pattern[0] {
+			continue
+		}
+		matched, end := 1, start+1
+		for ; end < len(text) && matched < len(pattern); end++ {
+			if text[end] == pattern[matched] {
+				matched++
+			}
+		}
+		if matched < len(pattern) {
+			break
+		}
+		if span := end - start; shortest < 0 || span < shortest {
+			shortest = span
+		}
+	}
+	if shortest < 0 {
+		return 0
+	}
+
+	gaps := shortest - len(pattern)
+	if gaps >= fuzzyScoreLimit {
+		return 1
+	}
+	return fuzzyScoreLimit - gaps
+}
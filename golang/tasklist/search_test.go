@@ -0,0 +1,94 @@
+package tasklist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTaskList_Search(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddTaskToProject("training", "Four Elements of Simple Design")
+	taskList.AddTaskToProject("training", "Interaction-Driven Design")
+	taskList.AddTaskToProject("training", "Designers meetup")
+	taskList.AddTaskToProject("training", "Redesign the donut shop")
+
+	tests := []struct {
+		name  string
+		terms []string
+		mode  SearchMode
+		want  []string
+	}{
+		{"word before prefix before substring", []string{"design"}, SearchText, []string{"3", "4", "5", "6"}},
+		{"every term must match", []string{"DESIGN", "simple"}, SearchText, []string{"3"}},
+		{"ranked by score", []string{"donut"}, SearchText, []string{"6", "1"}},
+		{"nothing found", []string{"cake"}, SearchText, nil},
+		{"regexp", []string{`^(eat|destroy)\b`}, SearchRegexp, []string{"1", "2"}},
+		{"fuzzy", []string{"dnts"}, SearchFuzzy, []string{"1", "6"}},
+		{"equal scores keep the project order", []string{"desgn"}, SearchFuzzy, []string{"3", "4", "5", "6"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := taskList.Search(tt.terms, tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, result := range results {
+				ids = append(ids, string(result.Task.GetID()))
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("expected tasks %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestTaskList_Search_scores(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("training", "Simple Design")
+	taskList.AddTaskToProject("training", "Designers meetup")
+	taskList.AddTaskToProject("training", "Redesign")
+
+	results, _ := taskList.Search([]string{"design"}, SearchText)
+	var scores []int
+	for _, result := range results {
+		scores = append(scores, result.Score)
+	}
+	if want := []int{wordScore, wordStartScore, substringScore}; !reflect.DeepEqual(scores, want) {
+		t.Errorf("expected scores %v, got %v", want, scores)
+	}
+	if results[0].ProjectName != "training" {
+		t.Errorf("expected the project of the result, got %q", results[0].ProjectName)
+	}
+}
+
+func TestTaskList_Search_invalidRegexp(t *testing.T) {
+	_, err := newTestTaskList().Search([]string{"(donuts"}, SearchRegexp)
+	if !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf("expected ErrInvalidPattern, got %v", err)
+	}
+}
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		description, term string
+		want              int
+	}{
+		{"donuts", "dnts", fuzzyScoreLimit - 2},
+		{"d o n u t s", "dnts", fuzzyScoreLimit - 7},
+		{"dxxxxxxxxxxonuts", "donuts", 1},
+		{"donuts", "stun", 0},
+		{"donuts", "", 0},
+	}
+	for _, tt := range tests {
+		if got := fuzzyScore(tt.description, tt.term); got != tt.want {
+			t.Errorf("fuzzyScore(%q, %q): expected %d, got %d", tt.description, tt.term, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package tasklist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// storedTaskList is the JSON representation of a TaskList.
+type storedTaskList struct {
+	LastID   int64           `json:"lastID"`
+	Projects []storedProject `json:"projects"`
+}
+
+type storedProject struct {
+	Name  string       `json:"name"`
+	Tasks []storedTask `json:"tasks"`
+}
+
+type storedTask struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Done        bool      `json:"done"`
+	Deadline    string    `json:"deadline,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Parent      string    `json:"parent,omitempty"`
+	DependsOn   []string  `json:"dependsOn,omitempty"`
+	Recurrence  string    `json:"recurrence,omitempty"`
+}
+
+// Storage loads the content of a TaskList and saves it after it changes.
+type Storage interface {
+	// Load fills l, which is expected to be empty, with the stored content.
+	Load(l *TaskList) error
+	// Save persists the current content of l.
+	Save(l *TaskList) error
+}
+
+// FileStorage loads and saves a TaskList as a JSON file.
+// Saves are serialized, so concurrent callers never leave an older content on disk.
+type FileStorage struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStorage returns a FileStorage backed by the file at path.
+func NewFileStorage(path string) *FileStorage {
+	return &FileStorage{
+		path: path,
+	}
+}
+
+// Load reads the file into l. A missing file is not an error and leaves l untouched.
+func (s *FileStorage) Load(l *TaskList) error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var stored storedTaskList
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return fmt.Errorf("could not decode %s: %w", s.path, err)
+	}
+
+	return l.restore(stored)
+}
+
+// Save writes l to the file, replacing it atomically.
+func (s *FileStorage) Save(l *TaskList) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(l.Snapshot().stored, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(s.path, data)
+}
+
+// writeFileAtomically replaces the file at path with data. The data is written
+// to a temporary file first and then renamed, so the file is never left half
+// written.
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Snapshot is a copy of the whole content of a TaskList at some point,
+// which Restore brings back.
+type Snapshot struct {
+	stored storedTaskList
+}
+
+// Snapshot returns a copy of the whole content of the TaskList.
+func (l *TaskList) Snapshot() Snapshot {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return Snapshot{stored: l.stored()}
+}
+
+// Equal reports whether s and other hold the same content.
+func (s Snapshot) Equal(other Snapshot) bool {
+	return reflect.DeepEqual(s.stored, other.stored)
+}
+
+// Restore replaces the content of the TaskList with a snapshot of it.
+// The change is recorded in the journal, if any, as an EventSnapshot.
+func (l *TaskList) Restore(snapshot Snapshot) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	stored := snapshot.stored
+	return l.record(Event{Type: EventSnapshot, Snapshot: &stored})
+}
+
+// stored returns the JSON representation of the whole TaskList.
+// The caller holds the lock.
+func (l *TaskList) stored() storedTaskList {
+	stored := storedTaskList{
+		LastID:   l.lastID,
+		Projects: make([]storedProject, 0),
+	}
+
+	for _, projectWithTasks := range l.projectsWithTasks() {
+		project := storedProject{
+			Name:  string(projectWithTasks.ProjectName),
+			Tasks: make([]storedTask, 0, len(projectWithTasks.Tasks)),
+		}
+		for _, task := range projectWithTasks.Tasks {
+			storedTask := storedTask{
+				ID:          string(task.id),
+				Description: task.description,
+				Done:        task.done,
+				Priority:    task.priority.String(),
+				Tags:        tagStrings(task.tags),
+				CreatedAt:   task.createdAt,
+				Parent:      string(task.parent),
+				Recurrence:  task.recurrence.String(),
+			}
+			for _, id := range task.dependsOn {
+				storedTask.DependsOn = append(storedTask.DependsOn, string(id))
+			}
+			if !task.deadline.IsEmpty() {
+				storedTask.Deadline = task.deadline.String()
+			}
+			project.Tasks = append(project.Tasks, storedTask)
+		}
+		stored.Projects = append(stored.Projects, project)
+	}
+
+	return stored
+}
+
+// restore replaces the content of the TaskList with the stored projects and tasks.
+func (l *TaskList) restore(stored storedTaskList) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.restoreStored(stored)
+}
+
+// restoreStored replaces the content of the TaskList. The caller holds the write lock.
+func (l *TaskList) restoreStored(stored storedTaskList) error {
+	for _, name := range l.repository.Projects() {
+		if err := l.repository.DeleteProject(name); err != nil {
+			return err
+		}
+	}
+
+	for _, project := range stored.Projects {
+		name := ProjectName(project.Name)
+		l.repository.AddProject(name)
+		for _, storedTask := range project.Tasks {
+			task, err := NewTask(storedTask.ID, storedTask.Description, storedTask.Done)
+			if err != nil {
+				return err
+			}
+			task.createdAt = storedTask.CreatedAt
+			task.parent = Identifier(storedTask.Parent)
+			if task.recurrence, err = ParseRecurrence(recurrenceOrNone(storedTask.Recurrence)); err != nil {
+				return err
+			}
+			for _, id := range storedTask.DependsOn {
+				task.dependsOn = withIDs(task.dependsOn, Identifier(id))
+			}
+			if task.priority, err = ParsePriority(priorityOrNone(storedTask.Priority)); err != nil {
+				return err
+			}
+			if task.tags, err = newTags(storedTask.Tags); err != nil {
+				return err
+			}
+			task.tags = withTags(nil, task.tags)
+			if storedTask.Deadline != "" {
+				deadline, err := NewDeadline(storedTask.Deadline)
+				if err != nil {
+					return fmt.Errorf("invalid deadline for task \"%s\": %w", storedTask.ID, err)
+				}
+				task.SetDeadline(deadline.in(l.timeZone()))
+			}
+			if err := l.repository.SaveTask(name, task); err != nil {
+				return err
+			}
+		}
+	}
+
+	l.lastID = stored.LastID
+	return nil
+}
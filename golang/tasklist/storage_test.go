@@ -0,0 +1,103 @@
+package tasklist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileStorage_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	storage := NewFileStorage(path)
+
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProjectWithID("abc", "secrets", "Destroy all humans.")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("training", "SOLID")
+	taskList.Check("1", false)
+	taskList.SetDeadline("2", "2020-07-30")
+	taskList.SetDeadline("1", "2020-07-30T17:00 Europe/Madrid")
+	taskList.SetPriority("abc", "high")
+	taskList.TagTask("abc", "+backend", "+urgent")
+	taskList.AddSubtask("abc", "Find the humans.")
+	taskList.AddDependency("abc", "2")
+	taskList.SetRecurrence("2", "every 2 weeks")
+
+	if err := storage.Save(taskList); err != nil {
+		t.Fatalf("could not save: %v", err)
+	}
+
+	loaded := newTestTaskList()
+	if err := storage.Load(loaded); err != nil {
+		t.Fatalf("could not load: %v", err)
+	}
+
+	if !reflect.DeepEqual(taskList.GetProjectsWithTasks(), loaded.GetProjectsWithTasks()) {
+		t.Errorf("loaded projects differ from saved ones:\n%+v\n%+v", taskList.GetProjectsWithTasks(), loaded.GetProjectsWithTasks())
+	}
+	if loaded.lastID != taskList.lastID {
+		t.Errorf("expected lastID %d, got %d", taskList.lastID, loaded.lastID)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the storage file to be left, got %d entries", len(entries))
+	}
+}
+
+func TestFileStorage_SaveKeepsTheTimeZoneOfDeadlines(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	storage := NewFileStorage(path)
+
+	taskList := NewTaskList(newTestTaskList().idGenerator, WithClock(fixedClock(testNow)), WithLocation(newYork))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Call mum.")
+	taskList.SetDeadline("1", "2024-05-01T17:00 Europe/Madrid")
+	if err := storage.Save(taskList); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTaskList(newTestTaskList().idGenerator, WithClock(fixedClock(testNow)), WithLocation(newYork))
+	if err := storage.Load(loaded); err != nil {
+		t.Fatal(err)
+	}
+	task, _, _ := loaded.GetTask("1")
+	if got := task.GetDeadline().String(); got != "2024-05-01T17:00 Europe/Madrid" {
+		t.Errorf("expected the deadline to keep its time zone once saved, got %q", got)
+	}
+}
+
+func TestFileStorage_LoadMissingFile(t *testing.T) {
+	storage := NewFileStorage(filepath.Join(t.TempDir(), "missing.json"))
+	taskList := newTestTaskList()
+
+	if err := storage.Load(taskList); err != nil {
+		t.Fatalf("expected no error for a missing file, got %v", err)
+	}
+	if len(taskList.GetProjectsWithTasks()) != 0 {
+		t.Errorf("expected an empty task list")
+	}
+}
+
+func TestFileStorage_LoadInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	taskList := newTestTaskList()
+
+	if err := NewFileStorage(path).Load(taskList); err == nil {
+		t.Errorf("expected an error for an invalid file")
+	}
+}
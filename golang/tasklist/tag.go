@@ -0,0 +1,84 @@
+package tasklist
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// tagPattern matches the characters allowed in tags, like in task IDs.
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Tag is a free-form label grouping tasks across projects, like "backend"
+// or "release-blocker". Tags are lowercase.
+type Tag string
+
+// NewTag validates tagString as a tag, ignoring a leading '+' or '#' and case.
+func NewTag(tagString string) (Tag, error) {
+	s := strings.TrimLeft(tagString, "+#")
+	if !tagPattern.MatchString(s) {
+		return "", NewError(ErrInvalidTag, "tag \"%s\" is not valid, only letters, digits, '-' and '_' are allowed.\n", tagString)
+	}
+
+	return Tag(strings.ToLower(s)), nil
+}
+
+// newTags validates every tag string.
+func newTags(tagStrings []string) ([]Tag, error) {
+	tags := make([]Tag, 0, len(tagStrings))
+	for _, tagString := range tagStrings {
+		tag, err := NewTag(tagString)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// cutTags removes from a task description the words like #backend, which
+// tag the task, and returns the tags found.
+func cutTags(description string) (string, []Tag) {
+	var tags []Tag
+	words := strings.Fields(description)
+	kept := words[:0]
+	for _, word := range words {
+		if strings.HasPrefix(word, "#") && tagPattern.MatchString(word[1:]) {
+			tags = append(tags, Tag(strings.ToLower(word[1:])))
+			continue
+		}
+		kept = append(kept, word)
+	}
+	if len(tags) == 0 {
+		return description, nil
+	}
+
+	return strings.Join(kept, " "), withTags(nil, tags)
+}
+
+// withTags returns a sorted set of tags with the ones of both slices.
+func withTags(tags []Tag, added []Tag) []Tag {
+	result := slices.Concat(tags, added)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// withoutTags returns the tags that are not removed.
+func withoutTags(tags []Tag, removed []Tag) []Tag {
+	var result []Tag
+	for _, tag := range tags {
+		if !slices.Contains(removed, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// tagStrings returns the tags as strings, or nil when there are none.
+func tagStrings(tags []Tag) []string {
+	var strs []string
+	for _, tag := range tags {
+		strs = append(strs, string(tag))
+	}
+	return strs
+}
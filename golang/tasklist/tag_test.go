@@ -0,0 +1,79 @@
+package tasklist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    Tag
+		wantErr bool
+	}{
+		{tag: "backend", want: "backend"},
+		{tag: "+Backend", want: "backend"},
+		{tag: "#release-blocker", want: "release-blocker"},
+		{tag: "+", wantErr: true},
+		{tag: "two words", wantErr: true},
+		{tag: "a!b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := NewTag(tt.tag)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Errorf("expected ErrInvalidTag for %q, got %v", tt.tag, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("expected %q, got %q, %v", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestTaskList_tags(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("secrets", "Eat more #Donuts. #food")
+	taskList.AddTaskToProject("training", "SOLID #backend")
+	taskList.AddTaskToProject("training", "Outside-In TDD")
+
+	first, _, _ := taskList.GetTask("1")
+	if first.GetDescription() != "Eat more #Donuts." || !reflect.DeepEqual(first.GetTags(), []Tag{"food"}) {
+		t.Errorf("expected the inline #food tag to be cut from the description, got %q with %v", first.GetDescription(), first.GetTags())
+	}
+
+	if err := taskList.TagTask("1", "+urgent", "+backend"); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskList.UntagTask("1", "food", "unknown"); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskList.TagTask("1", "a!b"); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %v", err)
+	}
+
+	var got []string
+	for _, tagWithTasks := range taskList.GetTagsWithTasks() {
+		for _, task := range tagWithTasks.Tasks {
+			got = append(got, string(tagWithTasks.Tag)+":"+string(task.GetID()))
+		}
+	}
+	want := []string{"backend:1", "backend:2", "urgent:1", ":3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	tagged, err := taskList.GetProjectsWithTasksTagged("#urgent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 1 || tagged[0].ProjectName != "secrets" || len(tagged[0].Tasks) != 1 {
+		t.Errorf("expected only task 1 in secrets, got %+v", tagged)
+	}
+}
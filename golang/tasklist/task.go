@@ -0,0 +1,130 @@
+package tasklist
+
+import (
+	"slices"
+	"time"
+)
+
+// Task describes an elementary task.
+// The tasks returned by a TaskList are copies: changing them does not change
+// the TaskList.
+type Task struct {
+	id          Identifier
+	description string
+	done        bool
+	deadline    Deadline
+	priority    Priority
+	// tags are sorted and never modified in place, so that copies of the
+	// task can share them.
+	tags      []Tag
+	createdAt time.Time
+	// parent is the ID of the task this one is a subtask of, in the same
+	// project, and empty for a top-level task.
+	parent Identifier
+	// dependsOn are the IDs of the tasks to be done before this one, sorted
+	// and never modified in place, like tags.
+	dependsOn []Identifier
+	// recurrence gives the deadline of the next occurrence of the task,
+	// added when it is checked.
+	recurrence Recurrence
+}
+
+// NewTask initializes a Task with the given ID, description and completion status.
+func NewTask(id string, description string, done bool) (*Task, error) {
+	return &Task{
+		id:          Identifier(id),
+		description: description,
+		done:        done,
+	}, nil
+}
+
+// clone returns a copy of the task.
+func (t *Task) clone() *Task {
+	c := *t
+	return &c
+}
+
+// GetParent returns the ID of the task this one is a subtask of, or an
+// empty Identifier for a top-level task.
+func (t *Task) GetParent() Identifier {
+	return t.parent
+}
+
+// GetID returns the task ID.
+func (t *Task) GetID() Identifier {
+	return t.id
+}
+
+// GetDescription returns the task description.
+func (t *Task) GetDescription() string {
+	return t.description
+}
+
+// IsDone returns whether the task is taskDone or not.
+func (t *Task) IsDone() bool {
+	return t.done
+}
+
+// SetDone changes the completion status of the task.
+func (t *Task) SetDone(done bool) {
+	t.done = done
+}
+
+// SetDeadline changes the deadline of the task.
+func (t *Task) SetDeadline(d Deadline) {
+	t.deadline = d
+}
+
+// GetDeadline returns the deadline of the task, which is empty when it has none.
+func (t *Task) GetDeadline() Deadline {
+	return t.deadline
+}
+
+// SetPriority changes the priority of the task.
+func (t *Task) SetPriority(p Priority) {
+	t.priority = p
+}
+
+// GetPriority returns the priority of the task, which is PriorityNone when it has none.
+func (t *Task) GetPriority() Priority {
+	return t.priority
+}
+
+// GetTags returns the sorted tags of the task.
+func (t *Task) GetTags() []Tag {
+	return slices.Clone(t.tags)
+}
+
+// HasTag reports whether the task is tagged with tag.
+func (t *Task) HasTag(tag Tag) bool {
+	return slices.Contains(t.tags, tag)
+}
+
+// GetDependencies returns the sorted IDs of the tasks this one depends on.
+func (t *Task) GetDependencies() []Identifier {
+	return slices.Clone(t.dependsOn)
+}
+
+// GetRecurrence returns the rule the task repeats by, which is empty when
+// it does not repeat.
+func (t *Task) GetRecurrence() Recurrence {
+	return t.recurrence
+}
+
+// GetCreatedAt returns when the task was added.
+func (t *Task) GetCreatedAt() time.Time {
+	return t.createdAt
+}
+
+// IsPreviousToCurrentDate reports whether the task is due according to the system clock.
+//
+// Deprecated: use IsDue with the time of a Clock, as TaskList does.
+func (t *Task) IsPreviousToCurrentDate() bool {
+	return t.IsDue(SystemClock{}.Now())
+}
+
+// IsDue reports whether the deadline of the task is not after the day of d,
+// both in the location of d. A task without a deadline is never due.
+func (t *Task) IsDue(d time.Time) bool {
+	return !t.deadline.IsEmpty() && !t.deadline.in(d.Location()).Date().After(dayOf(d))
+}
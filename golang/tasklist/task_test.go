@@ -1,4 +1,4 @@
-package main
+package tasklist
 
 import (
 	"testing"
@@ -16,7 +16,7 @@ func TestIsPreviousToCurrentDate(t *testing.T) {
 		{
 			name: "should return true as input was a valid dateString",
 			task: Task{
-				deadline: deadline{
+				deadline: Deadline{
 					date: parseSafeTime("2020-07-21"),
 				},
 			},
@@ -25,7 +25,7 @@ func TestIsPreviousToCurrentDate(t *testing.T) {
 		{
 			name: "also_valid_date",
 			task: Task{
-				deadline: deadline{
+				deadline: Deadline{
 					date: parseSafeTime("2020-07-30"),
 				},
 			},
@@ -45,10 +45,10 @@ func TestIsPreviousToCurrentDate(t *testing.T) {
 
 func TestTask_IsDue(t *testing.T) {
 	type taskFields struct {
-		id          identifier
+		id          Identifier
 		description string
 		taskDone    bool
-		deadline    deadline
+		deadline    Deadline
 	}
 
 	type testData struct {
@@ -65,7 +65,7 @@ func TestTask_IsDue(t *testing.T) {
 				id:          "0",
 				description: "",
 				taskDone:    false,
-				deadline: deadline{
+				deadline: Deadline{
 					date: parseSafeTime("2021-11-29"),
 				},
 			},
@@ -78,13 +78,24 @@ func TestTask_IsDue(t *testing.T) {
 				id:          "0",
 				description: "",
 				taskDone:    false,
-				deadline: deadline{
+				deadline: Deadline{
 					date: parseSafeTime("2050-01-01"),
 				},
 			},
 			date: parseSafeTime("2021-11-30"),
 			want: false,
 		},
+		{
+			name: "should compare dates in the location of the specified time",
+			taskFields: taskFields{
+				id: "0",
+				deadline: Deadline{
+					date: parseSafeTime("2021-11-30"),
+				},
+			},
+			date: time.Date(2021, time.November, 29, 22, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t1 *testing.T) {
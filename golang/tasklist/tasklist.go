@@ -0,0 +1,873 @@
+// Package tasklist manages projects and their tasks: adding them, checking
+// them as done, giving them deadlines and listing them grouped by project or
+// by date. A TaskList keeps its content in a TaskRepository and can be saved
+// to and loaded from a JSON file with a FileStorage.
+package tasklist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TaskList holds projects and their tasks. It is safe for concurrent use,
+// and the tasks it returns are copies of the ones it holds.
+type TaskList struct {
+	mu          sync.RWMutex
+	repository  TaskRepository
+	lastID      int64
+	idGenerator func(id int64) string
+	clock       Clock
+	// location is the time zone days are counted in, and the deadlines with
+	// a time of day are shown in, when not the one of the clock.
+	location *time.Location
+	// autoCheck checks a parent task once all its subtasks are done.
+	autoCheck bool
+	// journal records every change, when the TaskList was loaded from one.
+	journal *Journal
+}
+
+// Option configures a TaskList created with NewTaskList.
+type Option func(*TaskList)
+
+// WithRepository makes the TaskList keep its projects and tasks in repository
+// instead of the default in-memory one.
+func WithRepository(repository TaskRepository) Option {
+	return func(l *TaskList) {
+		l.repository = repository
+	}
+}
+
+// WithClock makes the TaskList read the current time from clock instead of
+// the system clock.
+func WithClock(clock Clock) Option {
+	return func(l *TaskList) {
+		l.clock = clock
+	}
+}
+
+// WithLocation makes the TaskList count days from the midnight of location,
+// and give the deadlines with a time of day in location, instead of the time
+// zone of its clock.
+func WithLocation(location *time.Location) Option {
+	return func(l *TaskList) {
+		l.location = location
+	}
+}
+
+// WithAutoCheck makes the TaskList check a task as soon as all its subtasks
+// are checked.
+func WithAutoCheck() Option {
+	return func(l *TaskList) {
+		l.autoCheck = true
+	}
+}
+
+// NewTaskList returns an empty TaskList. The IDs of the tasks added without a
+// custom ID are given by idGenerator, called with the number of IDs generated so far.
+func NewTaskList(idGenerator func(id int64) string, options ...Option) *TaskList {
+	l := &TaskList{
+		repository:  NewInMemoryTaskRepository(),
+		lastID:      0,
+		idGenerator: idGenerator,
+		clock:       SystemClock{},
+	}
+	for _, option := range options {
+		option(l)
+	}
+	if l.location != nil {
+		l.clock = clockIn{clock: l.clock, location: l.location}
+	}
+
+	return l
+}
+
+// timeZone returns the time zone days are counted in.
+func (l *TaskList) timeZone() *time.Location {
+	return l.clock.Now().Location()
+}
+
+// ProjectWithTasks contains a project name and the associated tasks.
+type ProjectWithTasks struct {
+	ProjectName ProjectName
+	Tasks       []*Task
+}
+
+// DateWithTasks contains a date and the tasks associated with it.
+// A zero date groups the tasks that have no such date.
+type DateWithTasks struct {
+	Date  time.Time
+	Tasks []*Task
+}
+
+// GetDeadlinesWithTasks returns the tasks grouped by deadline, sorted by date,
+// with the tasks without a deadline last.
+func (l *TaskList) GetDeadlinesWithTasks() []DateWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.getDatesWithTasks(func(task *Task) time.Time {
+		return task.deadline.Date()
+	})
+}
+
+// GetCreationDatesWithTasks returns the tasks grouped by the day they were added, sorted by date.
+func (l *TaskList) GetCreationDatesWithTasks() []DateWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.getDatesWithTasks(func(task *Task) time.Time {
+		createdAt := task.GetCreatedAt()
+		if createdAt.IsZero() {
+			return createdAt
+		}
+		return dayOf(createdAt)
+	})
+}
+
+// getDatesWithTasks groups all tasks by the date returned by dateOf. Tasks keep
+// the order they have within the projects, and projects are sorted alphabetically.
+func (l *TaskList) getDatesWithTasks(dateOf func(task *Task) time.Time) []DateWithTasks {
+	var datesWithTasks []DateWithTasks
+	for _, projectName := range l.repository.Projects() {
+		for _, task := range l.repository.Tasks(projectName) {
+			date := dateOf(task)
+			i := slices.IndexFunc(datesWithTasks, func(d DateWithTasks) bool {
+				return d.Date.Equal(date)
+			})
+			if i < 0 {
+				datesWithTasks = append(datesWithTasks, DateWithTasks{Date: date})
+				i = len(datesWithTasks) - 1
+			}
+			datesWithTasks[i].Tasks = append(datesWithTasks[i].Tasks, task.clone())
+		}
+	}
+
+	slices.SortStableFunc(datesWithTasks, func(a, b DateWithTasks) int {
+		switch {
+		case a.Date.Equal(b.Date):
+			return 0
+		case a.Date.IsZero():
+			return 1
+		case b.Date.IsZero():
+			return -1
+		}
+		return a.Date.Compare(b.Date)
+	})
+
+	return datesWithTasks
+}
+
+// GetProjectsWithTasksDueToday returns the Projects sorted alphabetically
+// with the associated tasks whose deadline is the current day, according to
+// the clock. Done tasks are left out unless includeDone is set, and so are
+// the projects without any such task.
+func (l *TaskList) GetProjectsWithTasksDueToday(includeDone bool) []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	today := dayOf(l.clock.Now())
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		return (includeDone || !task.done) && task.deadline.Date().Equal(today)
+	})
+}
+
+// GetProjectsWithOverdueTasks returns the Projects sorted alphabetically
+// with the associated tasks whose deadline is before the current day.
+// Done tasks are left out unless includeDone is set, and so are the
+// projects without any such task.
+func (l *TaskList) GetProjectsWithOverdueTasks(includeDone bool) []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	today := dayOf(l.clock.Now())
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		return (includeDone || !task.done) && !task.deadline.IsEmpty() && task.deadline.Date().Before(today)
+	})
+}
+
+// DefaultUpcomingDays is the number of days the interfaces list upcoming
+// tasks for when not told otherwise.
+const DefaultUpcomingDays = 7
+
+// GetProjectsWithUpcomingTasks returns the Projects sorted alphabetically
+// with the associated tasks whose deadline is within the given number of
+// days after the current day. Done tasks are left out unless includeDone is
+// set, and so are the projects without any such task.
+func (l *TaskList) GetProjectsWithUpcomingTasks(days int, includeDone bool) []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	today := dayOf(l.clock.Now())
+	last := today.AddDate(0, 0, days)
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		return (includeDone || !task.done) && task.deadline.Date().After(today) && !task.deadline.Date().After(last)
+	})
+}
+
+// projectsWithTasksMatching returns the projects with the tasks for which
+// match is true, leaving out the projects without any.
+func (l *TaskList) projectsWithTasksMatching(match func(task *Task) bool) []ProjectWithTasks {
+	var projectstWithTasks []ProjectWithTasks
+
+	for _, projectName := range l.repository.Projects() {
+		var tasks []*Task
+		for _, task := range l.repository.Tasks(projectName) {
+			if match(task) {
+				tasks = append(tasks, task.clone())
+			}
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		projectWithTasks := ProjectWithTasks{
+			ProjectName: projectName,
+			Tasks:       tasks,
+		}
+		projectstWithTasks = append(projectstWithTasks, projectWithTasks)
+	}
+
+	return projectstWithTasks
+}
+
+// GetProjectsWithTasks returns the Projects sorted alphabetically
+// with the associated tasks.
+func (l *TaskList) GetProjectsWithTasks() []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.projectsWithTasks()
+}
+
+func (l *TaskList) projectsWithTasks() []ProjectWithTasks {
+	var projectstWithTasks []ProjectWithTasks
+
+	for _, projectName := range l.repository.Projects() {
+		projectWithTasks := ProjectWithTasks{
+			ProjectName: projectName,
+			Tasks:       cloneTasks(l.repository.Tasks(projectName)),
+		}
+		projectstWithTasks = append(projectstWithTasks, projectWithTasks)
+	}
+
+	return projectstWithTasks
+}
+
+func cloneTasks(tasks []*Task) []*Task {
+	clones := make([]*Task, 0, len(tasks))
+	for _, task := range tasks {
+		clones = append(clones, task.clone())
+	}
+	return clones
+}
+
+// AddProject creates an empty project. The name must not be used by another project.
+func (l *TaskList) AddProject(name string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := validateProjectName(name); err != nil {
+		return err
+	}
+	pName := ProjectName(name)
+	if l.repository.HasProject(pName) {
+		return NewError(ErrDuplicateProject, "project \"%s\" already exists.\n", name)
+	}
+
+	return l.record(Event{Type: EventProjectAdded, Project: name})
+}
+
+// validateProjectName refuses the blank project names, which could not be
+// told apart when listed.
+func validateProjectName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return NewError(ErrInvalidProject, "project name cannot be empty.\n")
+	}
+	return nil
+}
+
+// AddTaskToProjectWithID adds a task with the given ID, which must be
+// valid and not used by any other task, and returns it. Like with
+// AddTaskToProject, words like !high in the description set its priority.
+func (l *TaskList) AddTaskToProjectWithID(taskID, projectNameStr, newTaskDescription string) (*Task, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pName := ProjectName(projectNameStr)
+	if !l.repository.HasProject(pName) {
+		return nil, NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", projectNameStr)
+	}
+
+	id, err := NewIdentifier(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if l.isTaskIDUsed(id) {
+		return nil, NewError(ErrDuplicateID, "task with ID \"%v\" already exists.\n", id)
+	}
+
+	return l.saveNewTask(Event{Type: EventTaskAdded, Project: projectNameStr, TaskID: string(id), Description: newTaskDescription})
+}
+
+// AddTaskToProject adds a task with a generated ID and returns it. The
+// words !high, !medium and !low are removed from the description and set
+// the priority of the task, and so are the words like #backend, which tag it.
+func (l *TaskList) AddTaskToProject(projectNameStr, newTaskDescription string) (*Task, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pName := ProjectName(projectNameStr)
+	if !l.repository.HasProject(pName) {
+		return nil, NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", projectNameStr)
+	}
+
+	id, err := l.nextTaskID()
+	if err != nil {
+		return nil, err
+	}
+
+	return l.saveNewTask(Event{Type: EventTaskAdded, Project: projectNameStr, TaskID: string(id), Description: newTaskDescription, LastID: l.lastID})
+}
+
+// AddSubtask adds a task with a generated ID under the task with parentID,
+// in the project of its parent.
+func (l *TaskList) AddSubtask(parentID, newTaskDescription string) (*Task, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, projectName, err := l.getTask(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := l.nextTaskID()
+	if err != nil {
+		return nil, err
+	}
+
+	return l.saveNewTask(Event{Type: EventTaskAdded, Project: string(projectName), Parent: parentID, TaskID: string(id), Description: newTaskDescription, LastID: l.lastID})
+}
+
+// saveNewTask records the EventTaskAdded e, with the priority and the tags
+// found in its description, and returns the added task.
+func (l *TaskList) saveNewTask(e Event) (*Task, error) {
+	var priority Priority
+	var tags []Tag
+	e.Description, priority = cutPriority(e.Description)
+	e.Description, tags = cutTags(e.Description)
+	e.Priority = priority.String()
+	e.Tags = tagStrings(tags)
+
+	if err := l.record(e); err != nil {
+		return nil, err
+	}
+
+	newTask, _, err := l.getTask(e.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	return newTask.clone(), nil
+}
+
+// Check marks the task with the given ID as done. A task with subtasks
+// not done yet is only checked when force is set, leaving them open.
+//
+// Checking a recurring task adds its next occurrence, with a new ID and the
+// following deadline of its recurrence, and returns it. The checked task
+// stops repeating. Otherwise, the returned task is nil.
+//
+// With WithAutoCheck, checking the last open subtask of a task checks the
+// task too.
+func (l *TaskList) Check(idString string, force bool) (*Task, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	task, projectName, err := l.getTask(idString)
+	if err != nil {
+		return nil, err
+	}
+	if open := countOpen(l.children(task.id, projectName)); open > 0 && !force {
+		return nil, NewError(ErrOpenSubtasks, "task \"%s\" still has %d open subtasks.\n", idString, open)
+	}
+
+	if err := l.record(Event{Type: EventTaskChecked, TaskID: idString}); err != nil {
+		return nil, err
+	}
+	next, err := l.repeat(task, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if l.autoCheck {
+		if err := l.checkParents(task, projectName); err != nil {
+			return nil, err
+		}
+	}
+	return next, nil
+}
+
+// repeat adds the next occurrence of task once it is checked, and returns
+// it, or nil when the task does not repeat.
+// The caller holds the write lock.
+func (l *TaskList) repeat(task *Task, projectName ProjectName) (*Task, error) {
+	if task.recurrence.IsEmpty() {
+		return nil, nil
+	}
+
+	today := dayOf(l.clock.Now())
+	due := today
+	if !task.deadline.IsEmpty() {
+		due = task.deadline.date
+	}
+	deadline := task.recurrence.nextDeadline(due, today)
+	if task.deadline.HasTime() {
+		deadline = deadline.atTimeOf(task.deadline.at)
+	}
+	id, err := l.nextTaskID()
+	if err != nil {
+		return nil, err
+	}
+
+	e := Event{
+		Type:        EventTaskAdded,
+		Project:     string(projectName),
+		Parent:      string(task.parent),
+		TaskID:      string(id),
+		Description: task.description,
+		Priority:    task.priority.String(),
+		Tags:        tagStrings(task.tags),
+		Deadline:    deadline.String(),
+		Recurrence:  task.recurrence.String(),
+		LastID:      l.lastID,
+	}
+	if err := l.record(e); err != nil {
+		return nil, err
+	}
+	if err := l.record(Event{Type: EventRecurrenceSet, TaskID: string(task.id)}); err != nil {
+		return nil, err
+	}
+
+	next, _, err := l.repository.FindTask(id)
+	if err != nil {
+		return nil, err
+	}
+	return next.clone(), nil
+}
+
+// checkParents checks the parents of task whose subtasks are all done.
+// The caller holds the write lock.
+func (l *TaskList) checkParents(task *Task, projectName ProjectName) error {
+	for parent := task.parent; parent != ""; {
+		parentTask, _, err := l.repository.FindTask(parent)
+		if err != nil || parentTask.done || countOpen(l.children(parent, projectName)) > 0 {
+			return nil
+		}
+		if err := l.record(Event{Type: EventTaskChecked, TaskID: string(parent)}); err != nil {
+			return err
+		}
+		parent = parentTask.parent
+	}
+	return nil
+}
+
+// Uncheck marks the task with the given ID as not done.
+func (l *TaskList) Uncheck(idString string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, _, err := l.getTask(idString); err != nil {
+		return err
+	}
+
+	return l.record(Event{Type: EventTaskUnchecked, TaskID: idString})
+}
+
+// children returns the subtasks of the task with the given ID, held by the
+// repository in its project.
+func (l *TaskList) children(id Identifier, projectName ProjectName) []*Task {
+	var children []*Task
+	for _, task := range l.repository.Tasks(projectName) {
+		if task.parent == id {
+			children = append(children, task)
+		}
+	}
+	return children
+}
+
+// descendants returns the subtasks of the task with the given ID, their
+// own subtasks following each of them.
+func (l *TaskList) descendants(id Identifier, projectName ProjectName) []*Task {
+	var descendants []*Task
+	for _, child := range l.children(id, projectName) {
+		descendants = append(descendants, child)
+		descendants = append(descendants, l.descendants(child.id, projectName)...)
+	}
+	return descendants
+}
+
+// countOpen returns the number of tasks not done.
+func countOpen(tasks []*Task) int {
+	open := 0
+	for _, task := range tasks {
+		if !task.done {
+			open++
+		}
+	}
+	return open
+}
+
+// GetTask returns the task with the given ID and the name of its project.
+func (l *TaskList) GetTask(idString string) (*Task, ProjectName, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	task, projectName, err := l.getTask(idString)
+	if err != nil {
+		return nil, "", err
+	}
+	return task.clone(), projectName, nil
+}
+
+// getTask returns the task held by the repository.
+func (l *TaskList) getTask(idString string) (*Task, ProjectName, error) {
+	id, err := NewIdentifier(idString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return l.repository.FindTask(id)
+}
+
+// DeleteTask removes the task with the given ID from its project. A task
+// that has subtasks is only removed, together with them, when force is set.
+func (l *TaskList) DeleteTask(idString string, force bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	task, projectName, err := l.getTask(idString)
+	if err != nil {
+		return err
+	}
+	if descendants := l.descendants(task.id, projectName); len(descendants) > 0 && !force {
+		return NewError(ErrTaskHasSubtasks, "task \"%s\" still has %d subtasks.\n", idString, len(descendants))
+	}
+
+	return l.record(Event{Type: EventTaskDeleted, TaskID: idString})
+}
+
+// DeleteProject removes a project. A project that still has tasks is only
+// removed, together with its tasks, when force is set.
+func (l *TaskList) DeleteProject(name string, force bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pName := ProjectName(name)
+	if !l.repository.HasProject(pName) {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", name)
+	}
+
+	if tasks := l.repository.Tasks(pName); len(tasks) > 0 && !force {
+		return NewError(ErrProjectNotEmpty, "project \"%s\" still has %d tasks.\n", name, len(tasks))
+	}
+
+	return l.record(Event{Type: EventProjectDeleted, Project: name})
+}
+
+// SetPriority gives the task with the given ID a priority parsed by ParsePriority.
+func (l *TaskList) SetPriority(id, priorityString string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	priority, err := ParsePriority(priorityString)
+	if err != nil {
+		return err
+	}
+	if _, _, err := l.getTask(id); err != nil {
+		return err
+	}
+
+	return l.record(Event{Type: EventPrioritySet, TaskID: id, Priority: priority.String()})
+}
+
+// TagTask adds tags, like "backend" or "+backend", to the task with the given ID.
+func (l *TaskList) TagTask(id string, tagStrings ...string) error {
+	return l.changeTags(EventTagsAdded, id, tagStrings)
+}
+
+// UntagTask removes tags from the task with the given ID. The tags the task
+// does not have are ignored.
+func (l *TaskList) UntagTask(id string, tagStrings ...string) error {
+	return l.changeTags(EventTagsRemoved, id, tagStrings)
+}
+
+func (l *TaskList) changeTags(eventType EventType, id string, tagStrings []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tags, err := newTags(tagStrings)
+	if err != nil {
+		return err
+	}
+	if _, _, err := l.getTask(id); err != nil {
+		return err
+	}
+
+	e := Event{Type: eventType, TaskID: id}
+	for _, tag := range tags {
+		e.Tags = append(e.Tags, string(tag))
+	}
+	return l.record(e)
+}
+
+// TagWithTasks contains a tag and the tasks tagged with it.
+// An empty tag groups the tasks without tags.
+type TagWithTasks struct {
+	Tag   Tag
+	Tasks []*Task
+}
+
+// GetTagsWithTasks returns the tasks grouped by tag, sorted alphabetically,
+// with the tasks without tags last. A task with several tags is listed
+// under each of them.
+func (l *TaskList) GetTagsWithTasks() []TagWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var tagsWithTasks []TagWithTasks
+	add := func(tag Tag, task *Task) {
+		i := slices.IndexFunc(tagsWithTasks, func(t TagWithTasks) bool {
+			return t.Tag == tag
+		})
+		if i < 0 {
+			tagsWithTasks = append(tagsWithTasks, TagWithTasks{Tag: tag})
+			i = len(tagsWithTasks) - 1
+		}
+		tagsWithTasks[i].Tasks = append(tagsWithTasks[i].Tasks, task.clone())
+	}
+	for _, projectName := range l.repository.Projects() {
+		for _, task := range l.repository.Tasks(projectName) {
+			if len(task.tags) == 0 {
+				add("", task)
+			}
+			for _, tag := range task.tags {
+				add(tag, task)
+			}
+		}
+	}
+
+	slices.SortStableFunc(tagsWithTasks, func(a, b TagWithTasks) int {
+		switch {
+		case a.Tag == b.Tag:
+			return 0
+		case a.Tag == "":
+			return 1
+		case b.Tag == "":
+			return -1
+		}
+		return strings.Compare(string(a.Tag), string(b.Tag))
+	})
+
+	return tagsWithTasks
+}
+
+// GetProjectsWithTasksTagged returns the Projects sorted alphabetically
+// with the associated tasks tagged with the given tag, leaving out the
+// projects without any such task.
+func (l *TaskList) GetProjectsWithTasksTagged(tagString string) ([]ProjectWithTasks, error) {
+	tag, err := NewTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.projectsWithTasksMatching(func(task *Task) bool {
+		return task.HasTag(tag)
+	}), nil
+}
+
+// Filter returns a new TaskList holding copies of the tasks selected by q,
+// in projects of the same names, so that any of its views only lists them.
+// The projects without a selected task are left out. Changes made to the
+// returned TaskList are not made to l.
+func (l *TaskList) Filter(q *Query) *TaskList {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	filtered := NewTaskList(l.idGenerator, WithClock(l.clock))
+	filtered.lastID = l.lastID
+	for _, projectName := range l.repository.Projects() {
+		for _, task := range l.repository.Tasks(projectName) {
+			if !q.Matches(task, projectName) {
+				continue
+			}
+			if !filtered.repository.HasProject(projectName) {
+				filtered.repository.AddProject(projectName)
+			}
+			filtered.repository.SaveTask(projectName, task.clone())
+		}
+	}
+
+	return filtered
+}
+
+// GetProjectsWithTasksByPriority returns the Projects sorted alphabetically
+// with the associated tasks sorted by priority, the highest first, and then
+// by deadline, the tasks without a deadline last.
+func (l *TaskList) GetProjectsWithTasksByPriority() []ProjectWithTasks {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	projectsWithTasks := l.projectsWithTasks()
+	for _, projectWithTasks := range projectsWithTasks {
+		slices.SortStableFunc(projectWithTasks.Tasks, func(a, b *Task) int {
+			if a.priority != b.priority {
+				return int(b.priority) - int(a.priority)
+			}
+			return compareDeadlines(a.deadline, b.deadline)
+		})
+	}
+
+	return projectsWithTasks
+}
+
+// compareDeadlines orders deadlines by date, the empty deadline last. On the
+// same day, the deadlines with a time of day come first, by time.
+func compareDeadlines(a, b Deadline) int {
+	switch {
+	case a.Date().Equal(b.Date()):
+		return compareTimes(a, b)
+	case a.IsEmpty():
+		return 1
+	case b.IsEmpty():
+		return -1
+	}
+	return a.Date().Compare(b.Date())
+}
+
+// compareTimes orders deadlines of the same day by time, the ones without a
+// time of day last.
+func compareTimes(a, b Deadline) int {
+	switch {
+	case a.HasTime() == b.HasTime():
+		return a.at.Compare(b.at)
+	case a.HasTime():
+		return -1
+	}
+	return 1
+}
+
+// RenameProject gives a project a new name, not used by another project.
+// Its tasks keep their IDs, deadlines and completion status.
+func (l *TaskList) RenameProject(oldName, newName string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.repository.HasProject(ProjectName(oldName)) {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", oldName)
+	}
+	if err := validateProjectName(newName); err != nil {
+		return err
+	}
+	if l.repository.HasProject(ProjectName(newName)) {
+		return NewError(ErrDuplicateProject, "project \"%s\" already exists.\n", newName)
+	}
+
+	return l.record(Event{Type: EventProjectRenamed, Project: oldName, NewProject: newName})
+}
+
+// EditTask replaces the description of the task with the given ID.
+func (l *TaskList) EditTask(idString, description string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, _, err := l.getTask(idString); err != nil {
+		return err
+	}
+
+	return l.record(Event{Type: EventTaskEdited, TaskID: idString, Description: description})
+}
+
+// MoveTask moves the task with the given ID to the end of another project.
+func (l *TaskList) MoveTask(idString, projectNameStr string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, projectName, err := l.getTask(idString)
+	if err != nil {
+		return err
+	}
+	if !l.repository.HasProject(ProjectName(projectNameStr)) {
+		return NewError(ErrProjectNotFound, "could not find a project with the name \"%s\".\n", projectNameStr)
+	}
+	if projectName == ProjectName(projectNameStr) {
+		return nil
+	}
+
+	return l.record(Event{Type: EventTaskMoved, TaskID: idString, Project: projectNameStr})
+}
+
+// maxIDCollisions is the number of used IDs in a row nextTaskID skips
+// before giving up, so that a generator returning used IDs cannot make it
+// loop forever while holding the lock.
+const maxIDCollisions = 100
+
+// nextTaskID returns the next generated ID, skipping the ones already used by custom IDs.
+func (l *TaskList) nextTaskID() (Identifier, error) {
+	for i := 0; i < maxIDCollisions; i++ {
+		id, err := NewIdentifier(l.idGenerator(l.lastID))
+		l.lastID++
+		if err != nil {
+			return "", fmt.Errorf("generated %w", err)
+		}
+		if !l.isTaskIDUsed(id) {
+			return id, nil
+		}
+	}
+	return "", NewError(ErrNoFreeID, "could not generate a task ID not used yet after %d attempts.\n", maxIDCollisions)
+}
+
+func (l *TaskList) isTaskIDUsed(id Identifier) bool {
+	_, _, err := l.repository.FindTask(id)
+	return err == nil
+}
+
+// SetDeadline gives the task with the given ID a deadline, parsed by
+// ParseDeadline against the clock of the TaskList. The "none" deadline
+// removes the one the task has.
+func (l *TaskList) SetDeadline(id string, deadlineString string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	deadline, err := ParseDeadline(deadlineString, l.clock)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := l.getTask(id); err != nil {
+		return err
+	}
+
+	return l.record(Event{Type: EventDeadlineSet, TaskID: id, Deadline: deadline.String()})
+}
+
+// SetRecurrence makes the task with the given ID repeat with the rule
+// parsed by ParseRecurrence. The "none" recurrence makes it stop repeating.
+func (l *TaskList) SetRecurrence(id string, recurrenceString string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	recurrence, err := ParseRecurrence(recurrenceString)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := l.getTask(id); err != nil {
+		return err
+	}
+
+	return l.record(Event{Type: EventRecurrenceSet, TaskID: id, Recurrence: recurrence.String()})
+}
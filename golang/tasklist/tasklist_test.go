@@ -0,0 +1,432 @@
+package tasklist
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.May, 1, 10, 30, 0, 0, time.UTC)
+
+// fixedClock is a Clock always telling the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func newTestTaskList() *TaskList {
+	taskList := NewTaskList(func(id int64) string {
+		return fmt.Sprintf("%v", id+1)
+	}, WithClock(fixedClock(testNow)))
+
+	return taskList
+}
+
+func TestGetProjectWithTasksNoError(t *testing.T) {
+	taskList := newTestTaskList()
+
+	projectName := "secrets"
+	taskList.AddProject(projectName)
+	taskList.AddTaskToProject(projectName, "Eat more donuts")
+	taskList.AddTaskToProject(projectName, "Destroy all human")
+
+	projectName = "amazing project"
+	taskList.AddProject(projectName)
+	taskList.AddTaskToProject(projectName, "Something really amazing")
+
+	projectName = "training"
+	taskList.AddProject(projectName)
+	taskList.AddTaskToProject(projectName, "SOLID")
+	taskList.AddTaskToProject(projectName, "Four Elements of Simple Design")
+	taskList.AddTaskToProject(projectName, "Coupling and Cohesion")
+
+	taskList.Check("1", false)
+	taskList.Check("3", false)
+	taskList.Check("5", false)
+
+	projectsWithTasks := taskList.GetProjectsWithTasks()
+	expectedProjectWithTasks := []ProjectWithTasks{
+		{
+			ProjectName: "amazing project",
+			Tasks: []*Task{
+				{
+					id:          Identifier("3"),
+					description: "Something really amazing",
+					done:        true,
+					createdAt:   testNow,
+				},
+			},
+		},
+		{
+			ProjectName: "secrets",
+			Tasks: []*Task{
+				{
+					id:          Identifier("1"),
+					description: "Eat more donuts",
+					done:        true,
+					createdAt:   testNow,
+				},
+				{
+					id:          Identifier("2"),
+					description: "Destroy all human",
+					done:        false,
+					createdAt:   testNow,
+				},
+			},
+		},
+		{
+			ProjectName: "training",
+			Tasks: []*Task{
+				{
+					id:          Identifier("4"),
+					description: "SOLID",
+					done:        false,
+					createdAt:   testNow,
+				},
+				{
+					id:          Identifier("5"),
+					description: "Four Elements of Simple Design",
+					done:        true,
+					createdAt:   testNow,
+				},
+				{
+					id:          Identifier("6"),
+					description: "Coupling and Cohesion",
+					done:        false,
+					createdAt:   testNow,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedProjectWithTasks, projectsWithTasks) {
+		t.Fatalf("expectedProjectWithTasks is not equal to projectsWithTasks:\n%+v, %+v", expectedProjectWithTasks, projectsWithTasks)
+	}
+}
+
+func TestTaskList_deleteTask(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts")
+	taskList.AddTaskToProject("secrets", "Destroy all human")
+
+	if err := taskList.DeleteTask("1", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := taskList.DeleteTask("1", false); err == nil {
+		t.Errorf("expected an error when deleting an unknown task")
+	}
+
+	want := []ProjectWithTasks{
+		{
+			ProjectName: "secrets",
+			Tasks: []*Task{
+				{
+					id:          Identifier("2"),
+					description: "Destroy all human",
+					createdAt:   testNow,
+				},
+			},
+		},
+	}
+	if got := taskList.GetProjectsWithTasks(); !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestTaskList_deleteProject(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("empty")
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts")
+
+	if err := taskList.DeleteProject("empty", false); err != nil {
+		t.Errorf("expected an empty project to be deleted, got %v", err)
+	}
+	if err := taskList.DeleteProject("secrets", false); err == nil {
+		t.Errorf("expected an error when deleting a project with tasks")
+	}
+	if err := taskList.DeleteProject("secrets", true); err != nil {
+		t.Errorf("expected a forced delete to succeed, got %v", err)
+	}
+	if err := taskList.DeleteProject("unknown", true); err == nil {
+		t.Errorf("expected an error when deleting an unknown project")
+	}
+	if got := taskList.GetProjectsWithTasks(); len(got) != 0 {
+		t.Errorf("expected no projects left, got %+v", got)
+	}
+}
+
+func TestTaskList_getDeadlineWithTasks(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts")
+	taskList.AddTaskToProject("secrets", "Destroy all human")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("training", "SOLID")
+	taskList.SetDeadline("2", "2021-11-30")
+	taskList.SetDeadline("3", "2021-11-29")
+
+	got := taskList.GetDeadlinesWithTasks()
+	want := []DateWithTasks{
+		{
+			Date:  parseSafeTime("2021-11-29"),
+			Tasks: []*Task{{id: "3", description: "SOLID", createdAt: testNow, deadline: Deadline{date: parseSafeTime("2021-11-29")}}},
+		},
+		{
+			Date:  parseSafeTime("2021-11-30"),
+			Tasks: []*Task{{id: "2", description: "Destroy all human", createdAt: testNow, deadline: Deadline{date: parseSafeTime("2021-11-30")}}},
+		},
+		{
+			Tasks: []*Task{{id: "1", description: "Eat more donuts", createdAt: testNow}},
+		},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestTaskList_errorsCanBeMatched(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProjectWithID("abc", "secrets", "Eat more donuts")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "unknown task", err: errorOf(taskList.Check("unknown", false)), want: ErrTaskNotFound},
+		{name: "unknown project", err: errorOf(taskList.AddTaskToProject("training", "SOLID")), want: ErrProjectNotFound},
+		{name: "invalid ID", err: errorOf(taskList.Check("a b", false)), want: ErrInvalidID},
+		{name: "duplicate ID", err: errorOf(taskList.AddTaskToProjectWithID("abc", "secrets", "SOLID")), want: ErrDuplicateID},
+		{name: "duplicate project", err: taskList.AddProject("secrets"), want: ErrDuplicateProject},
+		{name: "empty project name", err: taskList.AddProject(""), want: ErrInvalidProject},
+		{name: "blank new project name", err: taskList.RenameProject("secrets", " "), want: ErrInvalidProject},
+		{name: "invalid deadline", err: taskList.SetDeadline("abc", "someday"), want: ErrInvalidDeadline},
+		{name: "invalid recurrence", err: taskList.SetRecurrence("abc", "sometimes"), want: ErrInvalidRecurrence},
+		{name: "project not empty", err: taskList.DeleteProject("secrets", false), want: ErrProjectNotEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("expected %v to match %v", tt.err, tt.want)
+			}
+		})
+	}
+}
+
+func errorOf(_ *Task, err error) error {
+	return err
+}
+
+func TestTaskList_generatorOfUsedIDs(t *testing.T) {
+	taskList := NewTaskList(func(id int64) string { return "42" }, WithClock(fixedClock(testNow)))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+
+	if _, err := taskList.AddTaskToProject("secrets", "Destroy all humans."); !errors.Is(err, ErrNoFreeID) {
+		t.Errorf("expected ErrNoFreeID once the generator only returns used IDs, got %v", err)
+	}
+}
+
+func TestTaskList_dueTodayFollowsTheClock(t *testing.T) {
+	newYork := time.FixedZone("EDT", -4*60*60)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []Identifier
+	}{
+		{name: "the day before", now: time.Date(2024, time.April, 30, 23, 59, 59, 0, time.UTC)},
+		{name: "at midnight", now: time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC), want: []Identifier{"1"}},
+		{name: "just before the next midnight", now: time.Date(2024, time.May, 1, 23, 59, 59, 0, time.UTC), want: []Identifier{"1"}},
+		{name: "the next day", now: time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC), want: []Identifier{"2"}},
+		{name: "evening in New York, already the next day in UTC", now: time.Date(2024, time.April, 30, 22, 0, 0, 0, newYork)},
+		{name: "morning in Tokyo, still the day before in UTC", now: time.Date(2024, time.May, 1, 7, 0, 0, 0, tokyo), want: []Identifier{"1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskList := NewTaskList(func(id int64) string {
+				return fmt.Sprintf("%v", id+1)
+			}, WithClock(fixedClock(tt.now)))
+			taskList.AddProject("secrets")
+			taskList.AddTaskToProject("secrets", "Eat more donuts.")
+			taskList.AddTaskToProject("secrets", "Destroy all humans.")
+			taskList.SetDeadline("1", "2024-05-01")
+			taskList.SetDeadline("2", "2024-05-02")
+
+			var got []Identifier
+			for _, projectWithTasks := range taskList.GetProjectsWithTasksDueToday(false) {
+				for _, task := range projectWithTasks.Tasks {
+					got = append(got, task.GetID())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v to be due, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskList_relativeDeadlinesFollowTheClock(t *testing.T) {
+	// 23:00 on a tuesday in New York is already wednesday in UTC.
+	now := time.Date(2024, time.April, 30, 23, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+	taskList := NewTaskList(func(id int64) string { return "1" }, WithClock(fixedClock(now)))
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+
+	if err := taskList.SetDeadline("1", "tomorrow"); err != nil {
+		t.Fatal(err)
+	}
+
+	task, _, _ := taskList.GetTask("1")
+	if got := task.GetDeadline().String(); got != "2024-05-01" {
+		t.Errorf("expected tomorrow to be 2024-05-01, got %s", got)
+	}
+	if !task.GetCreatedAt().Equal(now) {
+		t.Errorf("expected the task to be created at %v, got %v", now, task.GetCreatedAt())
+	}
+}
+
+func TestTaskList_dueViews(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Eat more donuts.")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("training", "SOLID")
+	taskList.AddTaskToProject("training", "Outside-In TDD")
+	taskList.AddTaskToProject("training", "Coupling and Cohesion")
+	taskList.AddProject("empty")
+	taskList.SetDeadline("1", "2024-04-30")
+	taskList.SetDeadline("2", "2024-05-01")
+	taskList.SetDeadline("3", "2024-05-01")
+	taskList.SetDeadline("4", "2024-05-08")
+	taskList.SetDeadline("5", "2024-05-09")
+	taskList.Check("3", false)
+
+	tasksOf := func(projectsWithTasks []ProjectWithTasks) map[ProjectName][]Identifier {
+		ids := make(map[ProjectName][]Identifier)
+		for _, projectWithTasks := range projectsWithTasks {
+			for _, task := range projectWithTasks.Tasks {
+				ids[projectWithTasks.ProjectName] = append(ids[projectWithTasks.ProjectName], task.GetID())
+			}
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		got  []ProjectWithTasks
+		want map[ProjectName][]Identifier
+	}{
+		{name: "today", got: taskList.GetProjectsWithTasksDueToday(false), want: map[ProjectName][]Identifier{"secrets": {"2"}}},
+		{name: "today with done tasks", got: taskList.GetProjectsWithTasksDueToday(true), want: map[ProjectName][]Identifier{"secrets": {"2"}, "training": {"3"}}},
+		{name: "overdue", got: taskList.GetProjectsWithOverdueTasks(false), want: map[ProjectName][]Identifier{"secrets": {"1"}}},
+		{name: "upcoming", got: taskList.GetProjectsWithUpcomingTasks(7, false), want: map[ProjectName][]Identifier{"training": {"4"}}},
+		{name: "upcoming in 8 days", got: taskList.GetProjectsWithUpcomingTasks(8, false), want: map[ProjectName][]Identifier{"training": {"4", "5"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tasksOf(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if len(tt.got) != len(tt.want) {
+				t.Errorf("expected only the projects with matching tasks, got %+v", tt.got)
+			}
+		})
+	}
+}
+
+func TestTaskList_subtasks(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	subtask, err := taskList.AddSubtask("1", "Find the humans. !high")
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskList.AddSubtask("2", "Look under the bed.")
+
+	if subtask.GetParent() != "1" || subtask.GetPriority() != PriorityHigh {
+		t.Errorf("expected a high priority subtask of 1, got %+v", subtask)
+	}
+	if _, projectName, _ := taskList.GetTask("3"); projectName != "secrets" {
+		t.Errorf("expected the subtask in the project of its parent, got %q", projectName)
+	}
+	if _, err := taskList.AddSubtask("42", "Lost"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound for a missing parent, got %v", err)
+	}
+
+	if _, err := taskList.Check("1", false); !errors.Is(err, ErrOpenSubtasks) {
+		t.Errorf("expected ErrOpenSubtasks, got %v", err)
+	}
+	if _, err := taskList.Check("1", true); err != nil {
+		t.Errorf("expected a forced check to succeed, got %v", err)
+	}
+	if task, _, _ := taskList.GetTask("2"); task.IsDone() {
+		t.Errorf("expected a forced check to leave the subtasks open")
+	}
+
+	if err := taskList.MoveTask("1", "training"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"1", "2", "3"} {
+		if _, projectName, _ := taskList.GetTask(id); projectName != "training" {
+			t.Errorf("expected task %s to follow its parent to training, got %q", id, projectName)
+		}
+	}
+
+	if err := taskList.DeleteTask("1", false); !errors.Is(err, ErrTaskHasSubtasks) {
+		t.Errorf("expected ErrTaskHasSubtasks, got %v", err)
+	}
+	if err := taskList.DeleteTask("1", true); err != nil {
+		t.Fatal(err)
+	}
+	if tasks := taskList.GetProjectsWithTasks()[1].Tasks; len(tasks) != 0 {
+		t.Errorf("expected the subtasks to be deleted with their parent, got %+v", tasks)
+	}
+}
+
+func TestTaskList_moveSubtaskMakesItTopLevel(t *testing.T) {
+	taskList := newTestTaskList()
+	taskList.AddProject("secrets")
+	taskList.AddProject("training")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddSubtask("1", "Find the humans.")
+
+	taskList.MoveTask("2", "training")
+
+	if task, _, _ := taskList.GetTask("2"); task.GetParent() != "" {
+		t.Errorf("expected the moved subtask to leave its parent, got %q", task.GetParent())
+	}
+	if err := taskList.DeleteTask("1", false); err != nil {
+		t.Errorf("expected the parent to have no subtasks left, got %v", err)
+	}
+}
+
+func TestTaskList_autoCheck(t *testing.T) {
+	taskList := NewTaskList(newTestTaskList().idGenerator, WithClock(fixedClock(testNow)), WithAutoCheck())
+	taskList.AddProject("secrets")
+	taskList.AddTaskToProject("secrets", "Destroy all humans.")
+	taskList.AddSubtask("1", "Find the humans.")
+	taskList.AddSubtask("1", "Build a laser.")
+	taskList.AddSubtask("3", "Buy a lens.")
+
+	taskList.Check("2", false)
+	if task, _, _ := taskList.GetTask("1"); task.IsDone() {
+		t.Errorf("expected the parent to stay open while a subtask is open")
+	}
+
+	taskList.Check("4", false)
+	for _, id := range []string{"3", "1"} {
+		if task, _, _ := taskList.GetTask(id); !task.IsDone() {
+			t.Errorf("expected task %s to be checked with its last subtask", id)
+		}
+	}
+}